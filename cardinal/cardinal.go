@@ -103,6 +103,34 @@ func EachMessage[In any, Out any](wCtx WorldContext, fn func(TxData[In]) (Out, e
 	return nil
 }
 
+// RegisterMessageMiddleware registers middleware that wraps the processing of every message in EachMessage.
+// Middleware runs in the order it is registered in, before any middleware registered for the message's group.
+func RegisterMessageMiddleware(w *World, middleware ...MessageMiddleware) error {
+	if w.worldStage.Current() != worldstage.Init {
+		return eris.Errorf(
+			"world state is %s, expected %s to register message middleware",
+			w.worldStage.Current(),
+			worldstage.Init,
+		)
+	}
+	w.AddMessageMiddleware(middleware...)
+	return nil
+}
+
+// RegisterMessageGroupMiddleware registers middleware that wraps the processing of the messages in the given group in
+// EachMessage. Middleware runs in the order it is registered in.
+func RegisterMessageGroupMiddleware(w *World, group string, middleware ...MessageMiddleware) error {
+	if w.worldStage.Current() != worldstage.Init {
+		return eris.Errorf(
+			"world state is %s, expected %s to register message middleware",
+			w.worldStage.Current(),
+			worldstage.Init,
+		)
+	}
+	w.AddMessageGroupMiddleware(group, middleware...)
+	return nil
+}
+
 // RegisterMessage registers a message to the world. Cardinal will automatically set up HTTP routes that map to each
 // registered message. Message URLs are take the form of "group.name". A default group, "game", is used
 // unless the WithCustomMessageGroup option is used. Example: game.throw-rock
@@ -150,6 +178,37 @@ func RegisterQuery[Request any, Reply any](
 	return res
 }
 
+// RegisterEvent registers an event type under the given name, so that it can be emitted with EmitTypedEvent. The
+// registered events and the JSON schemas of their data are listed in the event catalog of the /world endpoint.
+func RegisterEvent[T any](w *World, name string) error {
+	if w.worldStage.Current() != worldstage.Init {
+		return eris.Errorf(
+			"world state is %s, expected %s to register event",
+			w.worldStage.Current(),
+			worldstage.Init,
+		)
+	}
+	return w.RegisterEvent(name, reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// EmitTypedEvent emits an event of a type registered with RegisterEvent that will be broadcast to all websocket
+// subscribers. The event is sent as a JSON object whose "type" field is the name of the event type and whose "data"
+// field is the event itself.
+func EmitTypedEvent[T any](wCtx WorldContext, event T) error {
+	eventType := reflect.TypeOf((*T)(nil)).Elem()
+	name, ok := wCtx.getEventNameByType(eventType)
+	if !ok {
+		return eris.Errorf("event type %s is not registered, register it with RegisterEvent", eventType)
+	}
+	return wCtx.EmitEvent(map[string]any{"type": name, "data": event})
+}
+
+// EmitEventTo emits an event that is only sent to the websocket subscribers that authenticated as the given persona.
+// Unlike events emitted with WorldContext.EmitEvent, it is not broadcast to every subscriber.
+func EmitEventTo(wCtx WorldContext, personaTag string, event map[string]any) error {
+	return wCtx.emitPrivateEvent(personaTag, event)
+}
+
 // Create creates a single entity in the world, and returns the id of the newly created entity.
 // At least 1 component must be provided.
 func Create(wCtx WorldContext, components ...types.Component) (_ types.EntityID, err error) {
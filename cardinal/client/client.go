@@ -0,0 +1,246 @@
+// Package client is a typed Go client for the HTTP API of a Cardinal game shard. It signs transactions with the sign
+// package and picks their nonces, so bots and tools only deal with the messages and queries of the game.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/persona/msg"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
+)
+
+const defaultTimeout = 30 * time.Second
+
+var (
+	ErrNoSigner           = errors.New("client has no signer")
+	ErrMessageFailed      = errors.New("message failed")
+	ErrTransactionPending = errors.New("transaction is waiting for its target tick")
+)
+
+// StatusError is returned when Cardinal responds to a request with an error status.
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("cardinal responded with %d: %s", e.StatusCode, e.Message)
+}
+
+// Client sends messages and queries to a Cardinal game shard.
+type Client struct {
+	url        string
+	httpClient *http.Client
+	privateKey *ecdsa.PrivateKey
+	personaTag string
+	debugKey   string
+
+	mux       sync.Mutex
+	namespace string
+	lastNonce uint64
+}
+
+type Option func(*Client)
+
+// WithSigner sets the private key that signs transactions and the persona they are sent on behalf of. The persona tag
+// can be left empty if the client only sends system transactions, e.g. to create a persona.
+func WithSigner(privateKey *ecdsa.PrivateKey, personaTag string) Option {
+	return func(c *Client) {
+		c.privateKey = privateKey
+		c.personaTag = personaTag
+	}
+}
+
+// WithHTTPClient sets the HTTP client that sends requests to Cardinal.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithNamespace sets the namespace of the world transactions are signed for. If it is not set, the namespace is
+// fetched from Cardinal before the first transaction is sent.
+func WithNamespace(namespace string) Option {
+	return func(c *Client) {
+		c.namespace = namespace
+	}
+}
+
+// WithDebugAPIKey sets the API key that authenticates requests to the debug routes, e.g. to evaluate CQL queries.
+func WithDebugAPIKey(key string) Option {
+	return func(c *Client) {
+		c.debugKey = key
+	}
+}
+
+// New returns a client for the Cardinal game shard at the given URL, e.g. http://localhost:4040.
+func New(url string, opts ...Option) *Client {
+	c := &Client{
+		url:        strings.TrimSuffix(url, "/"),
+		httpClient: &http.Client{Timeout: defaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// PersonaTag returns the persona tag the client sends transactions on behalf of.
+func (c *Client) PersonaTag() string {
+	return c.personaTag
+}
+
+// GetWorld returns the registered components, messages, queries and events of the world.
+func (c *Client) GetWorld(ctx context.Context) (*handler.GetWorldResponse, error) {
+	res := new(handler.GetWorldResponse)
+	if err := c.do(ctx, http.MethodGet, "/world", nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Namespace returns the namespace of the world.
+func (c *Client) Namespace(ctx context.Context) (string, error) {
+	c.mux.Lock()
+	namespace := c.namespace
+	c.mux.Unlock()
+	if namespace != "" {
+		return namespace, nil
+	}
+
+	world, err := c.GetWorld(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.namespace = world.Namespace
+	return c.namespace, nil
+}
+
+// CreatePersona claims the persona tag of the client for the address of its signer, and waits for the persona to be
+// created.
+func (c *Client) CreatePersona(ctx context.Context) error {
+	if c.privateKey == nil {
+		return eris.Wrap(ErrNoSigner, "")
+	}
+	_, err := SendMessage[msg.CreatePersona, msg.CreatePersonaResult](ctx, c, msg.CreatePersonaMessageName,
+		msg.CreatePersona{
+			PersonaTag:    c.personaTag,
+			SignerAddress: crypto.PubkeyToAddress(c.privateKey.PublicKey).Hex(),
+		},
+		WithGroup("persona"), asSystemTransaction())
+	return err
+}
+
+// EvaluateCQL returns the entities that match a CQL (Cardinal Query Language) query.
+func (c *Client) EvaluateCQL(ctx context.Context, cql string) ([]types.EntityStateElement, error) {
+	res := new(handler.CQLQueryResponse)
+	if err := c.do(ctx, http.MethodPost, "/cql", handler.CQLQueryRequest{CQL: cql}, res); err != nil {
+		return nil, err
+	}
+	return res.Results, nil
+}
+
+// ListReceipts returns the receipts of the ticks since the start tick of the request that are still available.
+func (c *Client) ListReceipts(
+	ctx context.Context, req handler.ListTxReceiptsRequest,
+) (*handler.ListTxReceiptsResponse, error) {
+	res := new(handler.ListTxReceiptsResponse)
+	if err := c.do(ctx, http.MethodPost, "/query/receipts/list", req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// sign signs a transaction containing the given message with the next nonce of the client.
+func (c *Client) sign(ctx context.Context, message any, system bool, opts []sign.Option) (*sign.Transaction, error) {
+	if c.privateKey == nil {
+		return nil, eris.Wrap(ErrNoSigner, "")
+	}
+	namespace, err := c.Namespace(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if system {
+		return sign.NewSystemTransaction(c.privateKey, namespace, c.nextNonce(), message, opts...)
+	}
+	return sign.NewTransaction(c.privateKey, c.personaTag, namespace, c.nextNonce(), message, opts...)
+}
+
+// nextNonce returns the nonce after the last nonce the client used. The first nonce is the current time in
+// microseconds, so nonces keep increasing when the client is restarted. Consecutive nonces keep transactions that
+// are signed at the same time within the nonce window of Cardinal, even if they reach it out of order. A signer should
+// not be shared by clients that send transactions at the same time, as their nonces could be too far apart for
+// Cardinal to accept.
+func (c *Client) nextNonce() uint64 {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.lastNonce == 0 {
+		c.lastNonce = uint64(time.Now().UnixMicro())
+	}
+	c.lastNonce++
+	return c.lastNonce
+}
+
+// do sends a request to Cardinal and decodes the JSON response into res, if it is not nil.
+func (c *Client) do(ctx context.Context, method, path string, body any, res any) error {
+	_, err := c.doWithStatus(ctx, method, path, body, res)
+	return err
+}
+
+// doWithStatus is like do, but also returns the status code of a successful response.
+func (c *Client) doWithStatus(ctx context.Context, method, path string, body any, res any) (int, error) {
+	var reqBody io.Reader
+	if body != nil {
+		bz, err := json.Marshal(body)
+		if err != nil {
+			return 0, eris.Wrap(err, "failed to encode request")
+		}
+		reqBody = bytes.NewReader(bz)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, reqBody)
+	if err != nil {
+		return 0, eris.Wrap(err, "")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.debugKey != "" && (path == "/cql" || strings.HasPrefix(path, "/debug/")) {
+		req.Header.Set("Authorization", "Bearer "+c.debugKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, eris.Wrapf(err, "failed to send request to %s", path)
+	}
+	defer resp.Body.Close()
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, eris.Wrap(err, "failed to read response")
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return 0, &StatusError{StatusCode: resp.StatusCode, Message: string(bz)}
+	}
+	if res != nil {
+		if err = json.Unmarshal(bz, res); err != nil {
+			return 0, eris.Wrap(err, "failed to decode response")
+		}
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,225 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"pkg.world.dev/world-engine/assert"
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/client"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+type Score struct {
+	Points int
+}
+
+func (Score) Name() string { return "score" }
+
+type ScoreMsg struct {
+	Points int
+}
+
+type ScoreResult struct {
+	Total int
+}
+
+type TotalRequest struct {
+	PersonaTag string
+}
+
+type TotalReply struct {
+	Total int
+}
+
+// newWorld returns a world that keeps a score for each persona, and a client for it.
+func newWorld(t *testing.T, opts ...client.Option) (*cardinal.TestFixture, *client.Client) {
+	tf := cardinal.NewTestFixture(t, nil, cardinal.WithDebugAPIKey("secret"))
+	world := tf.World
+	scores := map[string]types.EntityID{}
+	assert.NilError(t, cardinal.RegisterComponent[Score](world))
+	assert.NilError(t, cardinal.RegisterMessage[ScoreMsg, ScoreResult](world, "score"))
+	assert.NilError(t, cardinal.RegisterSystems(world, func(wCtx cardinal.WorldContext) error {
+		return cardinal.EachMessage[ScoreMsg, ScoreResult](wCtx,
+			func(tx cardinal.TxData[ScoreMsg]) (ScoreResult, error) {
+				if tx.Msg.Points < 0 {
+					return ScoreResult{}, errors.New("points must not be negative")
+				}
+				id, ok := scores[tx.Tx.PersonaTag]
+				if !ok {
+					var err error
+					if id, err = cardinal.Create(wCtx, Score{}); err != nil {
+						return ScoreResult{}, err
+					}
+					scores[tx.Tx.PersonaTag] = id
+				}
+				score, err := cardinal.GetComponent[Score](wCtx, id)
+				if err != nil {
+					return ScoreResult{}, err
+				}
+				score.Points += tx.Msg.Points
+				return ScoreResult{Total: score.Points}, cardinal.SetComponent(wCtx, id, score)
+			})
+	}))
+	assert.NilError(t, cardinal.RegisterQuery[TotalRequest, TotalReply](world, "total",
+		func(wCtx cardinal.WorldContext, req *TotalRequest) (*TotalReply, error) {
+			score, err := cardinal.GetComponent[Score](wCtx, scores[req.PersonaTag])
+			if err != nil {
+				return nil, err
+			}
+			return &TotalReply{Total: score.Points}, nil
+		}))
+	tf.StartWorld()
+
+	privateKey, err := crypto.GenerateKey()
+	assert.NilError(t, err)
+	opts = append([]client.Option{client.WithSigner(privateKey, "alice"), client.WithDebugAPIKey("secret")}, opts...)
+	return tf, client.New("http://"+tf.BaseURL, opts...)
+}
+
+// tickUntilDone ticks the world until f returns, and returns the error of f.
+func tickUntilDone(t *testing.T, tf *cardinal.TestFixture, f func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- f()
+	}()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case err := <-done:
+			return err
+		case <-timeout:
+			t.Fatal("timed out waiting for the world")
+		case <-time.After(10 * time.Millisecond):
+			tf.DoTick()
+		}
+	}
+}
+
+func TestCanSendMessagesAndQuery(t *testing.T) {
+	tf, c := newWorld(t)
+	ctx := context.Background()
+
+	assert.NilError(t, tickUntilDone(t, tf, func() error {
+		return c.CreatePersona(ctx)
+	}))
+
+	var rec *client.Receipt[ScoreResult]
+	assert.NilError(t, tickUntilDone(t, tf, func() (err error) {
+		rec, err = client.SendMessage[ScoreMsg, ScoreResult](ctx, c, "score", ScoreMsg{Points: 3})
+		return err
+	}))
+	assert.Equal(t, 3, rec.Result.Total)
+	assert.NilError(t, tickUntilDone(t, tf, func() (err error) {
+		rec, err = client.SendMessage[ScoreMsg, ScoreResult](ctx, c, "score", ScoreMsg{Points: 4})
+		return err
+	}))
+	assert.Equal(t, 7, rec.Result.Total)
+
+	// Errors of the message are reported along with its receipt.
+	err := tickUntilDone(t, tf, func() (err error) {
+		rec, err = client.SendMessage[ScoreMsg, ScoreResult](ctx, c, "score", ScoreMsg{Points: -1})
+		return err
+	})
+	assert.ErrorIs(t, err, client.ErrMessageFailed)
+	assert.Equal(t, 1, len(rec.Errors))
+
+	reply, err := client.Query[TotalRequest, TotalReply](ctx, c, "total", TotalRequest{PersonaTag: "alice"})
+	assert.NilError(t, err)
+	assert.Equal(t, 7, reply.Total)
+
+	received, err := client.GetReceipt[ScoreResult](ctx, c, rec.TxHash)
+	assert.ErrorIs(t, err, client.ErrMessageFailed)
+	assert.DeepEqual(t, rec.Errors, received.Errors)
+
+	receipts, err := c.ListReceipts(ctx, handler.ListTxReceiptsRequest{PersonaTag: "alice"})
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(receipts.Receipts))
+
+	entities, err := c.EvaluateCQL(ctx, "CONTAINS(score)")
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(entities))
+
+	_, err = client.Query[TotalRequest, TotalReply](ctx, c, "does-not-exist", TotalRequest{})
+	var statusErr *client.StatusError
+	assert.Check(t, errors.As(err, &statusErr))
+	assert.Equal(t, 404, statusErr.StatusCode)
+}
+
+func TestCanSubscribeToTheReceiptsOfAMessage(t *testing.T) {
+	tf, c := newWorld(t)
+	ctx := context.Background()
+	assert.NilError(t, tickUntilDone(t, tf, func() error {
+		return c.CreatePersona(ctx)
+	}))
+
+	sub, err := c.Subscribe(ctx, handler.MessageTopicPrefix+"game.score")
+	assert.NilError(t, err)
+	defer sub.Close()
+
+	var rec *client.Receipt[ScoreResult]
+	assert.NilError(t, tickUntilDone(t, tf, func() (err error) {
+		rec, err = client.SendMessage[ScoreMsg, ScoreResult](ctx, c, "score", ScoreMsg{Points: 5})
+		return err
+	}))
+
+	events, err := sub.Next()
+	assert.NilError(t, err)
+	assert.Equal(t, rec.Tick, events.Tick)
+	assert.Equal(t, 1, len(events.Receipts))
+	assert.Equal(t, rec.TxHash, events.Receipts[0].TxHash)
+	assert.Equal(t, `{"Total":5}`, string(events.Receipts[0].Result))
+}
+
+// holdFirstScore is a transport that holds the first score transaction until it is released, so that the
+// transactions sent after it reach Cardinal first.
+type holdFirstScore struct {
+	sent    atomic.Bool
+	held    chan struct{}
+	release chan struct{}
+}
+
+func (h *holdFirstScore) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/tx/game/score" && h.sent.CompareAndSwap(false, true) {
+		close(h.held)
+		<-h.release
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestTransactionsThatArriveOutOfOrderAreAccepted(t *testing.T) {
+	hold := &holdFirstScore{held: make(chan struct{}), release: make(chan struct{})}
+	tf, c := newWorld(t, client.WithHTTPClient(&http.Client{Transport: hold}))
+	ctx := context.Background()
+	assert.NilError(t, tickUntilDone(t, tf, func() error {
+		return c.CreatePersona(ctx)
+	}))
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := client.SendMessage[ScoreMsg, ScoreResult](ctx, c, "score", ScoreMsg{Points: 1})
+		first <- err
+	}()
+	<-hold.held
+	// The second transaction is signed well after the first one, but reaches Cardinal before it.
+	time.Sleep(10 * time.Millisecond)
+	assert.NilError(t, tickUntilDone(t, tf, func() error {
+		_, err := client.SendMessage[ScoreMsg, ScoreResult](ctx, c, "score", ScoreMsg{Points: 2})
+		return err
+	}))
+	close(hold.release)
+	assert.NilError(t, tickUntilDone(t, tf, func() error {
+		return <-first
+	}))
+
+	reply, err := client.Query[TotalRequest, TotalReply](ctx, c, "total", TotalRequest{PersonaTag: "alice"})
+	assert.NilError(t, err)
+	assert.Equal(t, 3, reply.Total)
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+var ErrSubscriptionRejected = errors.New("subscription rejected")
+
+// TopicEvents are the results of a tick that match the topics of a Subscription.
+type TopicEvents struct {
+	Tick         uint64                    `json:"tick"`
+	Events       []json.RawMessage         `json:"events,omitempty"`
+	Receipts     []TopicReceipt            `json:"receipts,omitempty"`
+	StateChanges []types.EntityStateChange `json:"stateChanges,omitempty"`
+}
+
+// TopicReceipt is the receipt of a transaction that matches the topics of a Subscription.
+type TopicReceipt struct {
+	TxHash     string          `json:"txHash"`
+	PersonaTag string          `json:"personaTag,omitempty"`
+	MsgName    string          `json:"msgName,omitempty"`
+	Result     json.RawMessage `json:"result"`
+	Errors     []string        `json:"errors"`
+}
+
+// Subscription receives the results of each tick that match its topics over the /events websocket.
+type Subscription struct {
+	conn *websocket.Conn
+}
+
+// Subscribe connects to the /events websocket and subscribes to the given topics, e.g. event:<type>,
+// message:<group>.<name>, receipt:<tx hash> or entity:<id>. The topic prefixes are defined in the handler package.
+func (c *Client) Subscribe(ctx context.Context, topics ...string) (*Subscription, error) {
+	url := "ws" + strings.TrimPrefix(c.url, "http") + "/events"
+	conn, res, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return nil, eris.Wrap(err, "failed to connect to events websocket")
+	}
+	_ = res.Body.Close()
+	if err = conn.WriteJSON(handler.SubscriptionRequest{Subscribe: topics}); err != nil {
+		_ = conn.Close()
+		return nil, eris.Wrap(err, "failed to send subscription request")
+	}
+
+	// The results of ticks that completed before the subscription was processed are sent unfiltered, so they are
+	// skipped until the subscription is acknowledged.
+	for {
+		_, bz, err := conn.ReadMessage()
+		if err != nil {
+			_ = conn.Close()
+			return nil, eris.Wrap(err, "failed to read subscription response")
+		}
+		var msg map[string]json.RawMessage
+		if json.Unmarshal(bz, &msg) != nil {
+			continue
+		}
+		if _, ok := msg["topics"]; !ok {
+			continue
+		}
+		var subRes handler.SubscriptionResponse
+		if err = json.Unmarshal(bz, &subRes); err != nil {
+			_ = conn.Close()
+			return nil, eris.Wrap(err, "failed to decode subscription response")
+		}
+		if subRes.Error != "" {
+			_ = conn.Close()
+			return nil, eris.Wrap(ErrSubscriptionRejected, subRes.Error)
+		}
+		return &Subscription{conn: conn}, nil
+	}
+}
+
+// Next blocks until the results of a tick that match the topics of the subscription are received.
+func (s *Subscription) Next() (*TopicEvents, error) {
+	events := new(TopicEvents)
+	if err := s.conn.ReadJSON(events); err != nil {
+		return nil, eris.Wrap(err, "failed to read events")
+	}
+	return events, nil
+}
+
+// Close closes the websocket connection of the subscription.
+func (s *Subscription) Close() error {
+	return eris.Wrap(s.conn.Close(), "")
+}
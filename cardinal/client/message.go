@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/sign"
+)
+
+const (
+	defaultGroup = "game"
+	// receiptPollInterval is how often the receipt of a message is polled for once Cardinal stopped waiting for it.
+	receiptPollInterval = 100 * time.Millisecond
+)
+
+// Receipt is the receipt of a transaction whose message has a result of type Out.
+type Receipt[Out any] struct {
+	TxHash string
+	Tick   uint64
+	Result Out
+	Errors []string
+}
+
+type callConfig struct {
+	group   string
+	version int
+	system  bool
+	txOpts  []sign.Option
+}
+
+// CallOption changes how a message is sent or a query is executed.
+type CallOption func(*callConfig)
+
+// WithGroup sets the group of the message or query. The default group is game.
+func WithGroup(group string) CallOption {
+	return func(c *callConfig) {
+		c.group = group
+	}
+}
+
+// WithVersion sets the version of the message or query. The latest version is used if it is not set.
+func WithVersion(version int) CallOption {
+	return func(c *callConfig) {
+		c.version = version
+	}
+}
+
+// WithTransactionOptions sets optional fields of the transaction of a message, e.g. sign.WithTargetTick.
+func WithTransactionOptions(opts ...sign.Option) CallOption {
+	return func(c *callConfig) {
+		c.txOpts = append(c.txOpts, opts...)
+	}
+}
+
+// asSystemTransaction sends the message in a system transaction rather than on behalf of the persona of the client.
+func asSystemTransaction() CallOption {
+	return func(c *callConfig) {
+		c.system = true
+	}
+}
+
+func newCallConfig(opts []CallOption) *callConfig {
+	cfg := &callConfig{group: defaultGroup}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// SendMessage sends a message in a transaction signed by the client, and waits for it to be executed. If the message
+// returned errors, its receipt is returned along with an error wrapping ErrMessageFailed.
+func SendMessage[In, Out any](
+	ctx context.Context, c *Client, name string, message In, opts ...CallOption,
+) (*Receipt[Out], error) {
+	cfg := newCallConfig(opts)
+	tx, err := c.sign(ctx, message, cfg.system, cfg.txOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	path := utils.GetTxURL(cfg.group, name)
+	if cfg.version > 0 {
+		path = utils.GetVersionedTxURL(cfg.group, cfg.version, name)
+	}
+	res := new(handler.PostTransactionResponse)
+	status, err := c.doWithStatus(ctx, http.MethodPost, path+"?wait=true", tx, res)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusAccepted || res.Receipt == nil {
+		// Cardinal stopped waiting before the transaction was executed.
+		return waitForReceipt[Out](ctx, c, res.TxHash)
+	}
+	return newReceipt[Out](*res.Receipt)
+}
+
+// Query executes a query and returns its reply.
+func Query[Req, Reply any](ctx context.Context, c *Client, name string, req Req, opts ...CallOption) (*Reply, error) {
+	cfg := newCallConfig(opts)
+	path := utils.GetQueryURL(cfg.group, name)
+	if cfg.version > 0 {
+		path = utils.GetVersionedQueryURL(cfg.group, cfg.version, name)
+	}
+	reply := new(Reply)
+	if err := c.do(ctx, http.MethodPost, path, req, reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// GetReceipt returns the receipt of a transaction whose message has a result of type Out. A *StatusError with a 404
+// status code is returned if the receipt is not available, and ErrTransactionPending if the transaction is waiting for
+// its target tick.
+func GetReceipt[Out any](ctx context.Context, c *Client, txHash string) (*Receipt[Out], error) {
+	var entry handler.ReceiptEntry
+	status, err := c.doWithStatus(ctx, http.MethodGet, "/receipt/"+txHash, nil, &entry)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusAccepted {
+		return nil, eris.Wrapf(ErrTransactionPending, "transaction %s", txHash)
+	}
+	return newReceipt[Out](entry)
+}
+
+func waitForReceipt[Out any](ctx context.Context, c *Client, txHash string) (*Receipt[Out], error) {
+	ticker := time.NewTicker(receiptPollInterval)
+	defer ticker.Stop()
+	for {
+		rec, err := GetReceipt[Out](ctx, c, txHash)
+		var statusErr *StatusError
+		notFound := errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+		if !notFound && !errors.Is(err, ErrTransactionPending) {
+			return rec, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, eris.Wrapf(ctx.Err(), "receipt of transaction %s is not available", txHash)
+		case <-ticker.C:
+		}
+	}
+}
+
+func newReceipt[Out any](entry handler.ReceiptEntry) (*Receipt[Out], error) {
+	rec := &Receipt[Out]{
+		TxHash: entry.TxHash,
+		Tick:   entry.Tick,
+		Errors: entry.Errors,
+	}
+	if len(entry.Errors) > 0 {
+		return rec, eris.Wrap(ErrMessageFailed, strings.Join(entry.Errors, "; "))
+	}
+	// The result was decoded as a generic JSON value, so it is converted back to its type.
+	bz, err := json.Marshal(entry.Result)
+	if err != nil {
+		return nil, eris.Wrap(err, "")
+	}
+	if err = json.Unmarshal(bz, &rec.Result); err != nil {
+		return nil, eris.Wrap(err, "failed to decode message result")
+	}
+	return rec, nil
+}
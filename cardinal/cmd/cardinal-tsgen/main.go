@@ -0,0 +1,77 @@
+// Command cardinal-tsgen generates a typed TypeScript client for a running Cardinal game shard. It introspects the
+// messages, queries, components and events of the world through the /world route.
+//
+// Usage:
+//
+//	cardinal-tsgen -url http://localhost:4040 -out client.ts
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/tsgen"
+)
+
+const requestTimeout = 10 * time.Second
+
+func main() {
+	url := flag.String("url", "http://localhost:4040", "URL of the Cardinal game shard")
+	out := flag.String("out", "", "file to write the client to, or stdout if empty")
+	flag.Parse()
+
+	if err := run(*url, *out); err != nil {
+		fmt.Fprintln(os.Stderr, eris.ToString(err, false))
+		os.Exit(1)
+	}
+}
+
+func run(url, out string) error {
+	world, err := getWorld(strings.TrimSuffix(url, "/"))
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return eris.Wrap(err, "failed to create output file")
+		}
+		defer f.Close()
+		w = f
+	}
+	return tsgen.Generate(w, world)
+}
+
+func getWorld(url string) (handler.GetWorldResponse, error) {
+	var world handler.GetWorldResponse
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/world", nil)
+	if err != nil {
+		return world, eris.Wrap(err, "")
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return world, eris.Wrap(err, "failed to get world")
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return world, eris.Errorf("failed to get world: %s", res.Status)
+	}
+	if err = json.NewDecoder(res.Body).Decode(&world); err != nil {
+		return world, eris.Wrap(err, "failed to decode world")
+	}
+	return world, nil
+}
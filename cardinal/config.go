@@ -8,12 +8,15 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rotisserie/eris"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
+	"pkg.world.dev/world-engine/cardinal/server"
+	"pkg.world.dev/world-engine/cardinal/telemetry"
 	"pkg.world.dev/world-engine/rift/credentials"
 )
 
@@ -22,6 +25,11 @@ const (
 	DefaultCardinalLogLevel          = "info"
 	DefaultRedisAddress              = "localhost:6379"
 	DefaultBaseShardSequencerAddress = "localhost:9601"
+	DefaultReceiptRetentionTicks     = 3600
+	DefaultEventRetentionTicks       = 3600
+	DefaultTargetTickHorizon         = 3600
+	DefaultTelemetryTraceExporter    = telemetry.TraceExporterDatadog
+	DefaultTelemetryOTLPProtocol     = telemetry.OTLPProtocolGRPC
 
 	// Toml config file related
 	configFilePathEnvVariable = "CARDINAL_CONFIG"
@@ -29,6 +37,9 @@ const (
 )
 
 var (
+	validTraceExporters = []string{telemetry.TraceExporterDatadog, telemetry.TraceExporterOTLP}
+	validOTLPProtocols  = []string{telemetry.OTLPProtocolGRPC, telemetry.OTLPProtocolHTTP}
+
 	validLogLevels = []string{
 		zerolog.DebugLevel.String(),
 		zerolog.InfoLevel.String(),
@@ -38,16 +49,28 @@ var (
 	}
 
 	defaultConfig = WorldConfig{
-		CardinalNamespace:         DefaultCardinalNamespace,
-		CardinalRollupEnabled:     false,
-		CardinalLogPretty:         false,
-		CardinalLogLevel:          DefaultCardinalLogLevel,
-		RedisAddress:              DefaultRedisAddress,
-		RedisPassword:             "",
-		BaseShardSequencerAddress: DefaultBaseShardSequencerAddress,
-		BaseShardRouterKey:        "",
-		TelemetryTraceEnabled:     false,
-		TelemetryProfilerEnabled:  false,
+		CardinalNamespace:             DefaultCardinalNamespace,
+		CardinalRollupEnabled:         false,
+		CardinalLogPretty:             false,
+		CardinalLogLevel:              DefaultCardinalLogLevel,
+		RedisAddress:                  DefaultRedisAddress,
+		RedisPassword:                 "",
+		BaseShardSequencerAddress:     DefaultBaseShardSequencerAddress,
+		BaseShardRouterKey:            "",
+		TelemetryTraceEnabled:         false,
+		TelemetryProfilerEnabled:      false,
+		TelemetryMetricsEnabled:       false,
+		TelemetryTraceExporter:        DefaultTelemetryTraceExporter,
+		TelemetryOTLPEndpoint:         "",
+		TelemetryOTLPProtocol:         DefaultTelemetryOTLPProtocol,
+		TelemetryOTLPInsecure:         false,
+		CardinalReceiptRetentionTicks: DefaultReceiptRetentionTicks,
+		CardinalEventRetentionTicks:   DefaultEventRetentionTicks,
+		CardinalTargetTickHorizon:     DefaultTargetTickHorizon,
+		CardinalSystemSignerAddress:   "",
+		CardinalDebugRoutesDisabled:   false,
+		CardinalDebugAPIKey:           "",
+		CardinalDebugSignerAddress:    "",
 	}
 )
 
@@ -81,6 +104,51 @@ type WorldConfig struct {
 
 	// TelemetryProfilerEnabled When true, Cardinal will run Datadog continuous profiling
 	TelemetryProfilerEnabled bool `mapstructure:"TELEMETRY_PROFILER_ENABLED"`
+
+	// TelemetryMetricsEnabled When true, Cardinal will serve Prometheus metrics on /metrics
+	TelemetryMetricsEnabled bool `mapstructure:"TELEMETRY_METRICS_ENABLED"`
+
+	// TelemetryTraceExporter Where traces are sent, either datadog (the DataDog agent) or otlp (any OpenTelemetry
+	// collector).
+	TelemetryTraceExporter string `mapstructure:"TELEMETRY_TRACE_EXPORTER"`
+
+	// TelemetryOTLPEndpoint The <host>:<port> of the OpenTelemetry collector. If it is not set, the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT env var is used.
+	TelemetryOTLPEndpoint string `mapstructure:"TELEMETRY_OTLP_ENDPOINT"`
+
+	// TelemetryOTLPProtocol The protocol of the OTLP trace exporter, either grpc or http.
+	TelemetryOTLPProtocol string `mapstructure:"TELEMETRY_OTLP_PROTOCOL"`
+
+	// TelemetryOTLPInsecure When true, the OTLP trace exporter does not use TLS.
+	TelemetryOTLPInsecure bool `mapstructure:"TELEMETRY_OTLP_INSECURE"`
+
+	// CardinalReceiptRetentionTicks The number of ticks worth of transaction receipts that are persisted to redis.
+	// Set to 0 to only keep receipts in memory.
+	CardinalReceiptRetentionTicks uint64 `mapstructure:"CARDINAL_RECEIPT_RETENTION_TICKS"`
+
+	// CardinalEventRetentionTicks The number of ticks worth of broadcast events that are persisted to redis, so that
+	// clients can catch up on the events they missed. Set to 0 to not keep any event history.
+	CardinalEventRetentionTicks uint64 `mapstructure:"CARDINAL_EVENT_RETENTION_TICKS"`
+
+	// CardinalTargetTickHorizon How many ticks beyond the next tick a transaction can target. Transactions that target
+	// a later tick are rejected. Set to 0 to not limit the target tick.
+	CardinalTargetTickHorizon uint64 `mapstructure:"CARDINAL_TARGET_TICK_HORIZON"`
+
+	// CardinalSystemSignerAddress The address that signs the system transactions of system-only messages. System-only
+	// messages are rejected if it is not set.
+	CardinalSystemSignerAddress string `mapstructure:"CARDINAL_SYSTEM_SIGNER_ADDRESS"`
+
+	// CardinalDebugRoutesDisabled When true, the debug routes (/debug/state and /cql), which can dump the entire game
+	// state, are not served.
+	CardinalDebugRoutesDisabled bool `mapstructure:"CARDINAL_DEBUG_ROUTES_DISABLED"`
+
+	// CardinalDebugAPIKey The API key that authenticates requests to the debug routes as a bearer token. The debug
+	// routes are open to anyone if neither it nor CARDINAL_DEBUG_SIGNER_ADDRESS is set, unless rollup mode is enabled,
+	// in which case they are disabled.
+	CardinalDebugAPIKey string `mapstructure:"CARDINAL_DEBUG_API_KEY"`
+
+	// CardinalDebugSignerAddress The address that can sign requests to the debug routes.
+	CardinalDebugSignerAddress string `mapstructure:"CARDINAL_DEBUG_SIGNER_ADDRESS"`
 }
 
 func loadWorldConfig() (*WorldConfig, error) {
@@ -129,6 +197,26 @@ func (w *WorldConfig) Validate() error {
 	if w.CardinalLogLevel == "" || !slices.Contains(validLogLevels, w.CardinalLogLevel) {
 		return eris.New("CARDINAL_LOG_LEVEL must be one of the following: " + strings.Join(validLogLevels, ", "))
 	}
+	if w.CardinalSystemSignerAddress != "" && !common.IsHexAddress(w.CardinalSystemSignerAddress) {
+		return eris.New("CARDINAL_SYSTEM_SIGNER_ADDRESS must be a hex address")
+	}
+	if w.CardinalDebugSignerAddress != "" && !common.IsHexAddress(w.CardinalDebugSignerAddress) {
+		return eris.New("CARDINAL_DEBUG_SIGNER_ADDRESS must be a hex address")
+	}
+
+	// Validate telemetry configs
+	if !slices.Contains(validTraceExporters, w.TelemetryTraceExporter) {
+		return eris.New("TELEMETRY_TRACE_EXPORTER must be one of the following: " +
+			strings.Join(validTraceExporters, ", "))
+	}
+	if !slices.Contains(validOTLPProtocols, w.TelemetryOTLPProtocol) {
+		return eris.New("TELEMETRY_OTLP_PROTOCOL must be one of the following: " + strings.Join(validOTLPProtocols, ", "))
+	}
+	if w.TelemetryOTLPEndpoint != "" {
+		if _, _, err := net.SplitHostPort(w.TelemetryOTLPEndpoint); err != nil {
+			return eris.Wrap(err, "TELEMETRY_OTLP_ENDPOINT must follow the format <host>:<port>")
+		}
+	}
 
 	// Validate base shard configs (only required when rollup mode is enabled)
 	if w.CardinalRollupEnabled {
@@ -146,6 +234,38 @@ func (w *WorldConfig) Validate() error {
 	return nil
 }
 
+// debugServerOptions returns the server options that configure the debug routes.
+func (w *WorldConfig) debugServerOptions() []server.Option {
+	var opts []server.Option
+	if w.CardinalDebugRoutesDisabled {
+		opts = append(opts, server.DisableDebugRoutes())
+	}
+	if w.CardinalRollupEnabled {
+		// Rollup mode is used in production, where the debug routes must not be open to anyone.
+		opts = append(opts, server.RequireDebugAuth())
+	}
+	if w.CardinalDebugAPIKey != "" {
+		opts = append(opts, server.WithDebugAPIKey(w.CardinalDebugAPIKey))
+	}
+	if w.CardinalDebugSignerAddress != "" {
+		opts = append(opts, server.WithDebugSignerAddress(w.CardinalDebugSignerAddress))
+	}
+	return opts
+}
+
+// telemetryOptions returns the telemetry options that configure where traces are exported.
+func (w *WorldConfig) telemetryOptions() []telemetry.Option {
+	if w.TelemetryTraceExporter != telemetry.TraceExporterOTLP {
+		return nil
+	}
+	return []telemetry.Option{telemetry.WithOTLPTraceExporter(telemetry.OTLPConfig{
+		Protocol:  w.TelemetryOTLPProtocol,
+		Endpoint:  w.TelemetryOTLPEndpoint,
+		Insecure:  w.TelemetryOTLPInsecure,
+		Namespace: w.CardinalNamespace,
+	})}
+}
+
 func (w *WorldConfig) setLogger() error {
 	// Set global logger level
 	level, err := zerolog.ParseLevel(w.CardinalLogLevel)
@@ -36,6 +36,19 @@ func TestWorldConfig_LoadFromEnv(t *testing.T) {
 		RedisPassword:             "bar",
 		BaseShardSequencerAddress: "localhost:8080",
 		BaseShardRouterKey:        "abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ01",
+		TelemetryMetricsEnabled:   true,
+		TelemetryTraceExporter:    "otlp",
+		TelemetryOTLPEndpoint:     "collector:4318",
+		TelemetryOTLPProtocol:     "http",
+		TelemetryOTLPInsecure:     true,
+
+		CardinalReceiptRetentionTicks: 100,
+		CardinalEventRetentionTicks:   200,
+		CardinalTargetTickHorizon:     300,
+		CardinalSystemSignerAddress:   "0x5e8d0a6d3d5fb5ab0a5e24e0a2ed0d1bdf1d2a38",
+		CardinalDebugRoutesDisabled:   true,
+		CardinalDebugAPIKey:           "debug-key",
+		CardinalDebugSignerAddress:    "0x9f3a1e0d7a3c0c2b9c3d1f5e4a6b8c7d2e1f0a9b",
 	}
 
 	// Set env vars to target config values
@@ -47,6 +60,18 @@ func TestWorldConfig_LoadFromEnv(t *testing.T) {
 	t.Setenv("REDIS_PASSWORD", wantCfg.RedisPassword)
 	t.Setenv("BASE_SHARD_SEQUENCER_ADDRESS", wantCfg.BaseShardSequencerAddress)
 	t.Setenv("BASE_SHARD_ROUTER_KEY", wantCfg.BaseShardRouterKey)
+	t.Setenv("TELEMETRY_METRICS_ENABLED", strconv.FormatBool(wantCfg.TelemetryMetricsEnabled))
+	t.Setenv("TELEMETRY_TRACE_EXPORTER", wantCfg.TelemetryTraceExporter)
+	t.Setenv("TELEMETRY_OTLP_ENDPOINT", wantCfg.TelemetryOTLPEndpoint)
+	t.Setenv("TELEMETRY_OTLP_PROTOCOL", wantCfg.TelemetryOTLPProtocol)
+	t.Setenv("TELEMETRY_OTLP_INSECURE", strconv.FormatBool(wantCfg.TelemetryOTLPInsecure))
+	t.Setenv("CARDINAL_RECEIPT_RETENTION_TICKS", strconv.FormatUint(wantCfg.CardinalReceiptRetentionTicks, 10))
+	t.Setenv("CARDINAL_EVENT_RETENTION_TICKS", strconv.FormatUint(wantCfg.CardinalEventRetentionTicks, 10))
+	t.Setenv("CARDINAL_TARGET_TICK_HORIZON", strconv.FormatUint(wantCfg.CardinalTargetTickHorizon, 10))
+	t.Setenv("CARDINAL_SYSTEM_SIGNER_ADDRESS", wantCfg.CardinalSystemSignerAddress)
+	t.Setenv("CARDINAL_DEBUG_ROUTES_DISABLED", strconv.FormatBool(wantCfg.CardinalDebugRoutesDisabled))
+	t.Setenv("CARDINAL_DEBUG_API_KEY", wantCfg.CardinalDebugAPIKey)
+	t.Setenv("CARDINAL_DEBUG_SIGNER_ADDRESS", wantCfg.CardinalDebugSignerAddress)
 
 	gotCfg, err := loadWorldConfig()
 	assert.NilError(t, err)
@@ -105,6 +130,50 @@ func TestWorldConfig_Validate_LogLevel(t *testing.T) {
 	})
 }
 
+func TestWorldConfig_Validate_Telemetry(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     WorldConfig
+		wantErr bool
+	}{
+		{
+			name: "With OTLP over HTTP",
+			cfg: defaultConfigWithOverrides(WorldConfig{
+				TelemetryTraceExporter: "otlp",
+				TelemetryOTLPProtocol:  "http",
+				TelemetryOTLPEndpoint:  "localhost:4318",
+			}),
+			wantErr: false,
+		},
+		{
+			name:    "With an unknown trace exporter",
+			cfg:     defaultConfigWithOverrides(WorldConfig{TelemetryTraceExporter: "jaeger"}),
+			wantErr: true,
+		},
+		{
+			name:    "With an unknown OTLP protocol",
+			cfg:     defaultConfigWithOverrides(WorldConfig{TelemetryOTLPProtocol: "udp"}),
+			wantErr: true,
+		},
+		{
+			name:    "With an OTLP endpoint without a port",
+			cfg:     defaultConfigWithOverrides(WorldConfig{TelemetryOTLPEndpoint: "localhost"}),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.wantErr {
+				assert.IsError(t, err)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
+
 func TestWorldConfig_Validate_RollupMode(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -0,0 +1,76 @@
+package cardinal
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+var _ EventManager = &eventManager{}
+
+type EventManager interface {
+	RegisterEvent(name string, eventType reflect.Type) error
+	GetEventNameByType(eventType reflect.Type) (string, bool)
+	BuildEventFields() []types.EventDetail
+}
+
+type eventManager struct {
+	registeredEvents       map[string]reflect.Type
+	registeredEventsByType map[reflect.Type]string
+}
+
+func newEventManager() EventManager {
+	return &eventManager{
+		registeredEvents:       map[string]reflect.Type{},
+		registeredEventsByType: map[reflect.Type]string{},
+	}
+}
+
+// RegisterEvent registers an event type under the given name. Both the name and the type must be unique, and the type
+// must be a struct so that its fields can be described in the event catalog.
+func (m *eventManager) RegisterEvent(name string, eventType reflect.Type) error {
+	if name == "" {
+		return eris.New("event name must not be empty")
+	}
+	if eventType.Kind() != reflect.Struct {
+		return eris.Errorf("event %q must be a struct, got %s", name, eventType.Kind())
+	}
+	if _, ok := m.registeredEvents[name]; ok {
+		return eris.Errorf("event %q is already registered", name)
+	}
+	if existing, ok := m.registeredEventsByType[eventType]; ok {
+		return eris.Errorf("event type %s is already registered as %q", eventType, existing)
+	}
+	m.registeredEvents[name] = eventType
+	m.registeredEventsByType[eventType] = name
+	return nil
+}
+
+// GetEventNameByType returns the name the given event type was registered with.
+func (m *eventManager) GetEventNameByType(eventType reflect.Type) (string, bool) {
+	name, ok := m.registeredEventsByType[eventType]
+	return name, ok
+}
+
+// BuildEventFields returns the catalog of registered events, ordered by name.
+func (m *eventManager) BuildEventFields() []types.EventDetail {
+	events := make([]types.EventDetail, 0, len(m.registeredEvents))
+	for name, eventType := range m.registeredEvents {
+		// The event type is a struct, which always has a schema.
+		schema, _ := jsonschema.ReflectFromType(eventType).MarshalJSON()
+		events = append(events, types.EventDetail{
+			Name:   name,
+			Fields: types.GetFieldInformation(eventType),
+			Schema: schema,
+		})
+	}
+	slices.SortFunc(events, func(a, b types.EventDetail) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return events
+}
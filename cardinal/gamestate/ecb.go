@@ -46,6 +46,9 @@ type EntityCommandBuffer struct {
 	archIDToComps  VolatileStorage[types.ArchetypeID, []types.ComponentMetadata]
 	pendingArchIDs []types.ArchetypeID
 
+	// parent is the buffer this buffer was forked from. It is nil unless the buffer was created by Fork.
+	parent *EntityCommandBuffer
+
 	// OpenTelemetry tracer
 	tracer trace.Tracer
 }
@@ -67,6 +70,7 @@ func NewEntityCommandBuffer(storage PrimitiveStorage[string]) (*EntityCommandBuf
 		// This field cannot be set until RegisterComponents is called
 		typeToComponent: nil,
 
+		parent: nil,
 		tracer: otel.Tracer("ecb"),
 	}
 
@@ -430,6 +434,10 @@ func (m *EntityCommandBuffer) ArchetypeCount() int {
 
 // Close closes the manager.
 func (m *EntityCommandBuffer) Close() error {
+	// A fork shares its dbStorage with the buffer it was forked from, so it is left open.
+	if m.parent != nil {
+		return nil
+	}
 	ctx := context.Background()
 	err := eris.Wrap(m.dbStorage.Close(ctx), "")
 	// todo: make error general to storage and not redis specific
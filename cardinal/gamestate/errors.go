@@ -10,6 +10,8 @@ var (
 	ErrComponentNotOnEntity              = errors.New("component not on entity")
 	ErrEntityMustHaveAtLeastOneComponent = errors.New("entities must have at least 1 component")
 	ErrMustRegisterComponent             = errors.New("must register component")
+	ErrNotAFork                          = errors.New("state changes can only be listed for a fork")
+	ErrCannotFinalizeFork                = errors.New("state changes of a fork cannot be finalized")
 
 	// ErrComponentMismatchWithSavedState is an error that is returned when a ComponentID from
 	// the saved state is not found in the passed in list of components.
@@ -0,0 +1,255 @@
+package gamestate
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/filter"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+// Fork returns a copy-on-write view of the command buffer. The view starts out with the same state as the buffer,
+// including its pending state changes. State changes made to the view are kept in memory and never reach the buffer
+// or the dbStorage layer, so the view can be discarded once it is no longer needed.
+func (m *EntityCommandBuffer) Fork() (Manager, error) {
+	compValues, err := cloneVolatileStorage(m.compValues, nil)
+	if err != nil {
+		return nil, err
+	}
+	compValuesToDelete, err := cloneVolatileStorage(m.compValuesToDelete, nil)
+	if err != nil {
+		return nil, err
+	}
+	active, err := cloneVolatileStorage(m.activeEntities, func(a activeEntities) activeEntities {
+		// swapRemove modifies the ids in place, so the view needs its own copy of them.
+		return activeEntities{ids: slices.Clone(a.ids), modified: a.modified}
+	})
+	if err != nil {
+		return nil, err
+	}
+	entityIDToArchID, err := cloneVolatileStorage(m.entityIDToArchID, nil)
+	if err != nil {
+		return nil, err
+	}
+	entityIDToOriginArchID, err := cloneVolatileStorage(m.entityIDToOriginArchID, nil)
+	if err != nil {
+		return nil, err
+	}
+	archIDToComps, err := cloneVolatileStorage(m.archIDToComps, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EntityCommandBuffer{
+		dbStorage:          m.dbStorage,
+		compValues:         compValues,
+		compValuesToDelete: compValuesToDelete,
+		typeToComponent:    m.typeToComponent,
+
+		activeEntities: active,
+
+		nextEntityIDSaved: m.nextEntityIDSaved,
+		pendingEntityIDs:  m.pendingEntityIDs,
+		isEntityIDLoaded:  m.isEntityIDLoaded,
+
+		entityIDToArchID:       entityIDToArchID,
+		entityIDToOriginArchID: entityIDToOriginArchID,
+
+		archIDToComps:  archIDToComps,
+		pendingArchIDs: slices.Clone(m.pendingArchIDs),
+
+		parent: m,
+		tracer: m.tracer,
+	}, nil
+}
+
+// StateChanges returns the state changes that were made to a view created by Fork, relative to the state of the
+// buffer it was forked from. Changes are ordered by entity ID. Entities that were created and then removed in the view
+// are left out.
+func (m *EntityCommandBuffer) StateChanges() ([]types.EntityStateChange, error) {
+	if m.parent == nil {
+		return nil, eris.Wrap(ErrNotAFork, "")
+	}
+	return m.stateChanges(m.parent, nil)
+}
+
+// PendingStateChanges returns the state changes of the buffer that have not been finalized yet, relative to the state
+// in the dbStorage layer. Changes are ordered by entity ID. If ids are given, only the changes to those entities are
+// returned. Entities that were created and then removed are left out.
+func (m *EntityCommandBuffer) PendingStateChanges(ids ...types.EntityID) ([]types.EntityStateChange, error) {
+	return m.stateChanges(m.committedView(), ids)
+}
+
+// stateChanges returns the state changes of the buffer relative to the given base buffer. If ids is not empty, only
+// the changes to those entities are returned.
+func (m *EntityCommandBuffer) stateChanges(
+	base *EntityCommandBuffer, ids []types.EntityID,
+) ([]types.EntityStateChange, error) {
+	touched, err := m.touchedEntities()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) > 0 {
+		touched = slices.DeleteFunc(touched, func(id types.EntityID) bool {
+			return !slices.Contains(ids, id)
+		})
+	}
+
+	changes := make([]types.EntityStateChange, 0, len(touched))
+	for _, id := range touched {
+		change, changed, err := m.entityStateChange(base, id)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// committedView returns a buffer without any pending state changes, which reads the state in the dbStorage layer. It
+// must only be read from.
+func (m *EntityCommandBuffer) committedView() *EntityCommandBuffer {
+	return &EntityCommandBuffer{
+		dbStorage:          m.dbStorage,
+		compValues:         NewMapStorage[compKey, any](),
+		compValuesToDelete: NewMapStorage[compKey, bool](),
+		typeToComponent:    m.typeToComponent,
+
+		activeEntities: NewMapStorage[types.ArchetypeID, activeEntities](),
+
+		entityIDToArchID:       NewMapStorage[types.EntityID, types.ArchetypeID](),
+		entityIDToOriginArchID: NewMapStorage[types.EntityID, types.ArchetypeID](),
+
+		// Archetype IDs are never reassigned, so the archetypes of stored entities are the same in both buffers.
+		archIDToComps: m.archIDToComps,
+
+		parent: nil,
+		tracer: m.tracer,
+	}
+}
+
+// touchedEntities returns the sorted IDs of the entities whose state may differ from the state of the base buffer.
+// This is a superset of the entities that were actually changed, as component values are also cached when they are
+// only read.
+func (m *EntityCommandBuffer) touchedEntities() ([]types.EntityID, error) {
+	compKeys, err := m.compValues.Keys()
+	if err != nil {
+		return nil, err
+	}
+	deletedKeys, err := m.compValuesToDelete.Keys()
+	if err != nil {
+		return nil, err
+	}
+	movedIDs, err := m.entityIDToOriginArchID.Keys()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := movedIDs
+	for _, key := range slices.Concat(compKeys, deletedKeys) {
+		ids = append(ids, key.entityID)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+// entityStateChange compares the state of the given entity with its state in the base buffer. False is returned if the
+// state of the entity is unchanged.
+func (m *EntityCommandBuffer) entityStateChange(
+	base *EntityCommandBuffer, id types.EntityID,
+) (types.EntityStateChange, bool, error) {
+	change := types.EntityStateChange{ID: id}
+
+	comps, exists, err := m.entityComponents(id)
+	if err != nil {
+		return change, false, err
+	}
+	parentComps, parentExists, err := base.entityComponents(id)
+	if err != nil {
+		return change, false, err
+	}
+	if !exists && !parentExists {
+		return change, false, nil
+	}
+	change.Created = !parentExists
+	change.Removed = !exists
+
+	for _, comp := range comps {
+		bz, err := m.GetComponentForEntityInRawJSON(comp, id)
+		if err != nil {
+			return change, false, err
+		}
+		if parentExists && filter.MatchComponentMetadata(parentComps, comp) {
+			parentBz, err := base.GetComponentForEntityInRawJSON(comp, id)
+			if err != nil {
+				return change, false, err
+			}
+			if bytes.Equal(bz, parentBz) {
+				continue
+			}
+		}
+		if change.Components == nil {
+			change.Components = map[string]json.RawMessage{}
+		}
+		change.Components[comp.Name()] = bz
+	}
+	if exists {
+		for _, comp := range parentComps {
+			if !filter.MatchComponentMetadata(comps, comp) {
+				change.RemovedComponents = append(change.RemovedComponents, comp.Name())
+			}
+		}
+	}
+
+	changed := change.Created || change.Removed || len(change.Components) > 0 || len(change.RemovedComponents) > 0
+	return change, changed, nil
+}
+
+// entityComponents returns the components that are on the given entity. False is returned if the entity does not
+// exist.
+func (m *EntityCommandBuffer) entityComponents(id types.EntityID) ([]types.ComponentMetadata, bool, error) {
+	if _, err := m.entityIDToArchID.Get(id); err != nil {
+		// Entities that were removed are still in storage until the tick is finalized.
+		if _, err := m.entityIDToOriginArchID.Get(id); err == nil {
+			return nil, false, nil
+		}
+	}
+	comps, err := m.GetComponentTypesForEntity(id)
+	if err != nil {
+		if eris.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return comps, true, nil
+}
+
+// cloneVolatileStorage returns a copy of the given storage. If clone is not nil, it is used to copy each value.
+func cloneVolatileStorage[K comparable, V any](
+	storage VolatileStorage[K, V], clone func(V) V,
+) (VolatileStorage[K, V], error) {
+	cpy := NewMapStorage[K, V]()
+	keys, err := storage.Keys()
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		value, err := storage.Get(key)
+		if err != nil {
+			return nil, err
+		}
+		if clone != nil {
+			value = clone(value)
+		}
+		if err = cpy.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return cpy, nil
+}
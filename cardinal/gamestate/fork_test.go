@@ -0,0 +1,80 @@
+package gamestate_test
+
+import (
+	"context"
+	"testing"
+
+	"pkg.world.dev/world-engine/assert"
+	"pkg.world.dev/world-engine/cardinal/gamestate"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+func TestForkStateChangesAreDiscarded(t *testing.T) {
+	ctx := context.Background()
+	manager := newCmdBufferForTest(t)
+	ids, err := manager.CreateManyEntities(3, fooComp)
+	assert.NilError(t, err)
+	assert.NilError(t, manager.SetComponentForEntity(fooComp, ids[0], Foo{Value: 1}))
+	assert.NilError(t, manager.FinalizeTick(ctx))
+
+	fork, err := manager.Fork()
+	assert.NilError(t, err)
+	// Reading a component does not change it.
+	_, err = fork.GetComponentForEntity(fooComp, ids[2])
+	assert.NilError(t, err)
+	assert.NilError(t, fork.SetComponentForEntity(fooComp, ids[0], Foo{Value: 2}))
+	assert.NilError(t, fork.AddComponentToEntity(barComp, ids[1]))
+	assert.NilError(t, fork.RemoveEntity(ids[2]))
+	created, err := fork.CreateEntity(barComp)
+	assert.NilError(t, err)
+
+	changes, err := fork.StateChanges()
+	assert.NilError(t, err)
+	assert.Equal(t, 4, len(changes))
+	assert.Equal(t, ids[0], changes[0].ID)
+	assert.Equal(t, `{"Value":2}`, string(changes[0].Components["foo"]))
+	assert.Equal(t, ids[1], changes[1].ID)
+	assert.Equal(t, `{"Value":0}`, string(changes[1].Components["bar"]))
+	assert.Equal(t, 1, len(changes[1].Components))
+	assert.DeepEqual(t, types.EntityStateChange{ID: ids[2], Removed: true}, changes[2])
+	assert.Equal(t, created, changes[3].ID)
+	assert.Check(t, changes[3].Created)
+
+	// The state of the manager is unchanged.
+	value, err := manager.GetComponentForEntity(fooComp, ids[0])
+	assert.NilError(t, err)
+	assert.Equal(t, Foo{Value: 1}, value)
+	comps, err := manager.GetComponentTypesForEntity(ids[1])
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(comps))
+	entities, err := manager.GetEntitiesForArchID(0)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, ids, entities)
+	_, err = manager.CreateEntity(fooComp)
+	assert.NilError(t, err)
+
+	assert.ErrorIs(t, fork.FinalizeTick(ctx), gamestate.ErrCannotFinalizeFork)
+	_, err = manager.StateChanges()
+	assert.ErrorIs(t, err, gamestate.ErrNotAFork)
+}
+
+func TestPendingStateChangesAreRelativeToStorage(t *testing.T) {
+	ctx := context.Background()
+	manager := newCmdBufferForTest(t)
+	ids, err := manager.CreateManyEntities(2, fooComp)
+	assert.NilError(t, err)
+	assert.NilError(t, manager.FinalizeTick(ctx))
+
+	assert.NilError(t, manager.SetComponentForEntity(fooComp, ids[0], Foo{Value: 1}))
+	assert.NilError(t, manager.SetComponentForEntity(fooComp, ids[1], Foo{Value: 2}))
+	changes, err := manager.PendingStateChanges(ids[1])
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, ids[1], changes[0].ID)
+	assert.Equal(t, `{"Value":2}`, string(changes[0].Components["foo"]))
+
+	assert.NilError(t, manager.FinalizeTick(ctx))
+	changes, err = manager.PendingStateChanges()
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(changes))
+}
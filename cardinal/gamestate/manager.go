@@ -58,4 +58,11 @@ type Manager interface {
 	Reader
 	Writer
 	ToReadOnly() Reader
+	// Fork returns a copy-on-write view of the manager whose state changes are discarded instead of being finalized.
+	Fork() (Manager, error)
+	// StateChanges returns the state changes made to a view created by Fork.
+	StateChanges() ([]types.EntityStateChange, error)
+	// PendingStateChanges returns the state changes that have not been finalized yet, optionally limited to the given
+	// entities.
+	PendingStateChanges(ids ...types.EntityID) ([]types.EntityStateChange, error)
 }
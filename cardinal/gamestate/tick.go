@@ -40,6 +40,13 @@ func (m *EntityCommandBuffer) FinalizeTick(ctx context.Context) error {
 	ctx, span := m.tracer.Start(ddotel.ContextWithStartOptions(ctx, ddtracer.Measured()), "ecb.tick.finalize")
 	defer span.End()
 
+	if m.parent != nil {
+		err := eris.Wrap(ErrCannotFinalizeFork, "")
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return err
+	}
+
 	pipe, err := m.makePipeOfRedisCommands(ctx)
 	if err != nil {
 		span.SetStatus(codes.Error, eris.ToString(err, true))
@@ -34,12 +34,26 @@ type MessageOption[In, Out any] func(mt *MessageType[In, Out])
 
 // MessageType manages a user defined state transition message struct.
 type MessageType[In, Out any] struct {
-	id         types.MessageID
-	isIDSet    bool
-	name       string
-	group      string
-	inEVMType  *ethereumAbi.Type
-	outEVMType *ethereumAbi.Type
+	id             types.MessageID
+	isIDSet        bool
+	name           string
+	group          string
+	inEVMType      *ethereumAbi.Type
+	outEVMType     *ethereumAbi.Type
+	validator      func(In) error
+	stateValidator func(WorldContext, TxData[In]) error
+	roles          []string
+	systemOnly     bool
+	// version is the version of In. Previous versions of the message are still accepted, and their input is converted
+	// to In.
+	version          int
+	previousVersions []types.Message
+}
+
+// validatableMessage is implemented by messages that can validate their input before the transaction is added to
+// the transaction pool.
+type validatableMessage interface {
+	validate(wCtx WorldContext, msg any, tx *sign.Transaction) error
 }
 
 // NewMessageType creates a new message type. It accepts two generic type parameters: the first for the message input,
@@ -54,8 +68,9 @@ func NewMessageType[In, Out any](
 		panic(fmt.Sprintf("Invalid MessageType: %q: The In and Out must be both structs", name))
 	}
 	msg := &MessageType[In, Out]{
-		name:  name,
-		group: defaultGroup,
+		name:    name,
+		group:   defaultGroup,
+		version: defaultVersion,
 	}
 	for _, opt := range opts {
 		opt(msg)
@@ -64,6 +79,13 @@ func NewMessageType[In, Out any](
 		panic(fmt.Sprintf("Invalid MessageType: %q: message group and name must only contain alphanumerics, "+
 			"dashes (-), and/or underscores (_). Must also start/end with an alphanumeric.", msg.FullName()))
 	}
+	previousVersions := make([]int, 0, len(msg.previousVersions))
+	for _, previous := range msg.previousVersions {
+		previousVersions = append(previousVersions, previous.Version())
+	}
+	if err := validateVersions(msg.version, previousVersions); err != nil {
+		panic(fmt.Sprintf("Invalid MessageType: %q: %v", msg.FullName(), err))
+	}
 	return msg
 }
 
@@ -81,6 +103,20 @@ func (t *MessageType[In, Out]) IsEVMCompatible() bool {
 	return t.inEVMType != nil && t.outEVMType != nil
 }
 
+// Version returns the version of the message's input type. It is 1 unless the WithMsgVersion option is used.
+func (t *MessageType[In, Out]) Version() int {
+	return t.version
+}
+
+// PreviousVersions returns the older versions of the message that are still accepted.
+func (t *MessageType[In, Out]) PreviousVersions() []types.Message {
+	return t.previousVersions
+}
+
+func (t *MessageType[In, Out]) IsSystemOnly() bool {
+	return t.systemOnly
+}
+
 func (t *MessageType[In, Out]) ID() types.MessageID {
 	if !t.isIDSet {
 		panic(fmt.Sprintf("id on msg %q is not set", t.name))
@@ -129,9 +165,16 @@ func (t *MessageType[In, Out]) GetReceipt(wCtx WorldContext, hash types.TxHash)
 	return value, errs, true
 }
 
+// Each calls fn with each of the transactions of this MessageType in the current tick, wrapped with the registered
+// message middleware. Errors are added to the transaction's receipt, otherwise the result is.
 func (t *MessageType[In, Out]) Each(wCtx WorldContext, fn func(TxData[In]) (Out, error)) {
+	handler := t.messageHandler(wCtx, fn)
 	for _, txData := range t.In(wCtx) {
-		if result, err := fn(txData); err != nil {
+		result, err := handler(wCtx, t, TxData[any]{Hash: txData.Hash, Msg: txData.Msg, Tx: txData.Tx})
+		if err == nil {
+			err = t.setResultFromHandler(wCtx, txData.Hash, result)
+		}
+		if err != nil {
 			err = eris.Wrap(err, "")
 			wCtx.Logger().Err(err).Msgf("tx %s from %s encountered an error with message=%+v and stack trace:\n %s",
 				txData.Hash,
@@ -140,12 +183,48 @@ func (t *MessageType[In, Out]) Each(wCtx WorldContext, fn func(TxData[In]) (Out,
 				eris.ToString(err, true),
 			)
 			t.AddError(wCtx, txData.Hash, err)
-		} else {
-			t.SetResult(wCtx, txData.Hash, result)
 		}
 	}
 }
 
+// setResultFromHandler sets the result returned by a MessageHandler. The result can be replaced by middleware, so it
+// is checked to be of this MessageType's output type.
+func (t *MessageType[In, Out]) setResultFromHandler(wCtx WorldContext, hash types.TxHash, result any) error {
+	out, ok := result.(Out)
+	if !ok {
+		return eris.Errorf("expected result of type %T, got %T", *new(Out), result)
+	}
+	t.SetResult(wCtx, hash, out)
+	return nil
+}
+
+// validate runs the validators of this MessageType against the given message. The stateless validator runs first, so
+// the state validator is only called with messages that are well-formed.
+func (t *MessageType[In, Out]) validate(wCtx WorldContext, msg any, tx *sign.Transaction) error {
+	in, ok := msg.(In)
+	if !ok {
+		return eris.Errorf("expected message of type %T, got %T", *new(In), msg)
+	}
+	if t.validator != nil {
+		if err := t.validator(in); err != nil {
+			return err
+		}
+	}
+	if t.stateValidator != nil {
+		return t.stateValidator(wCtx, TxData[In]{
+			Hash: types.TxHash(tx.HashHex()),
+			Msg:  in,
+			Tx:   tx,
+		})
+	}
+	return nil
+}
+
+// requiredRoles returns the roles that allow a persona to send this message. Any persona can send it if there are none.
+func (t *MessageType[In, Out]) requiredRoles() []string {
+	return t.roles
+}
+
 // In extracts all the TxData in the tx pool that match this MessageType's ID.
 func (t *MessageType[In, Out]) In(wCtx WorldContext) []TxData[In] {
 	tq := wCtx.getTxPool()
@@ -249,6 +328,64 @@ func WithCustomMessageGroup[In, Out any](group string) MessageOption[In, Out] {
 	}
 }
 
+// WithMsgValidator sets a function that validates the message before its transaction is added to the transaction
+// pool. Transactions with messages that fail validation are rejected by the server and never reach any system.
+func WithMsgValidator[In, Out any](validator func(In) error) MessageOption[In, Out] {
+	return func(mt *MessageType[In, Out]) {
+		mt.validator = validator
+	}
+}
+
+// WithMsgStateValidator sets a function that validates the message against the current world state before its
+// transaction is added to the transaction pool. It only runs once the signature of the transaction has been verified.
+// The given WorldContext is read-only, and reflects the state as of the last completed tick.
+func WithMsgStateValidator[In, Out any](validator func(WorldContext, TxData[In]) error) MessageOption[In, Out] {
+	return func(mt *MessageType[In, Out]) {
+		mt.stateValidator = validator
+	}
+}
+
+// WithMsgRequiredRoles restricts the message to personas that have been granted at least one of the given roles.
+// Transactions from other personas are rejected before they are added to the transaction pool.
+func WithMsgRequiredRoles[In, Out any](roles ...string) MessageOption[In, Out] {
+	return func(mt *MessageType[In, Out]) {
+		mt.roles = append(mt.roles, roles...)
+	}
+}
+
+// WithMsgSystemOnly restricts the message to system transactions (see sign.NewSystemTransaction) signed by the system
+// signer address, which is set with CARDINAL_SYSTEM_SIGNER_ADDRESS or WithSystemSignerAddress. Transactions from
+// personas are rejected by the server. This is useful for messages sent by trusted game services rather than players.
+func WithMsgSystemOnly[In, Out any]() MessageOption[In, Out] {
+	return func(mt *MessageType[In, Out]) {
+		mt.systemOnly = true
+	}
+}
+
+// WithMsgVersion sets the version of the message's input type. Versions start at 1, and should be incremented each time
+// the input type changes in a way that breaks existing clients. The latest version of a message is served under both
+// /tx/<group>/<name> and /tx/<group>/v<version>/<name>.
+func WithMsgVersion[In, Out any](version int) MessageOption[In, Out] {
+	return func(mt *MessageType[In, Out]) {
+		mt.version = version
+	}
+}
+
+// WithMsgPreviousVersion keeps accepting an older version of the message, served under /tx/<group>/v<version>/<name>.
+// Transactions sent to the older version are decoded as OldIn and converted to the latest input type with adapt
+// before they are added to the transaction pool, so systems only need to handle the latest version. Transactions
+// recovered from the base shard are decoded with the version they were sent with, so previous versions should be kept
+// for as long as the base shard may hold transactions that were sent with them.
+func WithMsgPreviousVersion[OldIn, In, Out any](version int, adapt func(OldIn) (In, error)) MessageOption[In, Out] {
+	return func(mt *MessageType[In, Out]) {
+		mt.previousVersions = append(mt.previousVersions, &previousMessageVersion[OldIn, In, Out]{
+			MessageType: mt,
+			version:     version,
+			adapt:       adapt,
+		})
+	}
+}
+
 // -------------------------- Helpers --------------------------
 
 func isStruct[T any]() bool {
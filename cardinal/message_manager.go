@@ -15,6 +15,9 @@ type MessageManager interface {
 	GetMessageByID(id types.MessageID) types.Message
 	GetMessageByFullName(fullName string) (types.Message, bool)
 	GetMessageByType(mType reflect.Type) (types.Message, bool)
+	AddMessageMiddleware(middleware ...MessageMiddleware)
+	AddMessageGroupMiddleware(group string, middleware ...MessageMiddleware)
+	GetMessageMiddleware(group string) []MessageMiddleware
 }
 
 type messageManager struct {
@@ -22,6 +25,10 @@ type messageManager struct {
 	registeredMessages       map[string]types.Message
 	registeredMessagesByType map[reflect.Type]types.Message
 	nextMessageID            types.MessageID
+	// middleware wraps the processing of all messages, and groupMiddleware wraps the processing of the messages in a
+	// given group.
+	middleware      []MessageMiddleware
+	groupMiddleware map[string][]MessageMiddleware
 }
 
 func newMessageManager() MessageManager {
@@ -29,6 +36,8 @@ func newMessageManager() MessageManager {
 		registeredMessages:       map[string]types.Message{},
 		registeredMessagesByType: map[reflect.Type]types.Message{},
 		nextMessageID:            1,
+		middleware:               nil,
+		groupMiddleware:          map[string][]MessageMiddleware{},
 	}
 }
 
@@ -84,6 +93,24 @@ func (m *messageManager) GetMessageByType(mType reflect.Type) (types.Message, bo
 	return msg, ok
 }
 
+// AddMessageMiddleware adds middleware that wraps the processing of all messages.
+func (m *messageManager) AddMessageMiddleware(middleware ...MessageMiddleware) {
+	m.middleware = append(m.middleware, middleware...)
+}
+
+// AddMessageGroupMiddleware adds middleware that wraps the processing of the messages in the given group.
+func (m *messageManager) AddMessageGroupMiddleware(group string, middleware ...MessageMiddleware) {
+	m.groupMiddleware[group] = append(m.groupMiddleware[group], middleware...)
+}
+
+// GetMessageMiddleware returns the middleware that wraps the processing of the messages in the given group, in the
+// order it runs in. Middleware for all messages runs before middleware for the group.
+func (m *messageManager) GetMessageMiddleware(group string) []MessageMiddleware {
+	middleware := make([]MessageMiddleware, 0, len(m.middleware)+len(m.groupMiddleware[group]))
+	middleware = append(middleware, m.middleware...)
+	return append(middleware, m.groupMiddleware[group]...)
+}
+
 // isMessageFullNameUnique checks if the message name already exist in messages map.
 func (m *messageManager) isMessageFullNameUnique(fullName string) error {
 	_, ok := m.registeredMessages[fullName]
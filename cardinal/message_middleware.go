@@ -0,0 +1,40 @@
+package cardinal
+
+import (
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+// MessageHandler processes a single transaction of a message and returns the result of the message.
+type MessageHandler func(wCtx WorldContext, msg types.Message, txData TxData[any]) (any, error)
+
+// MessageMiddleware wraps the processing of the transactions of a message by EachMessage. A middleware has access to
+// the transaction before and after next is called, and can reject the transaction by returning an error without
+// calling next. The error is added to the transaction's receipt, and the system's handler is never run for it.
+type MessageMiddleware func(next MessageHandler) MessageHandler
+
+// withMiddleware wraps the given handler with the given middleware. The first middleware is the outermost one, so it
+// is the first to see the transaction.
+func withMiddleware(handler MessageHandler, middleware []MessageMiddleware) MessageHandler {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// messageHandler adapts fn to a MessageHandler wrapped with the middleware registered for this MessageType.
+func (t *MessageType[In, Out]) messageHandler(wCtx WorldContext, fn func(TxData[In]) (Out, error)) MessageHandler {
+	handler := func(_ WorldContext, _ types.Message, txData TxData[any]) (any, error) {
+		in, ok := txData.Msg.(In)
+		if !ok {
+			return nil, eris.Errorf("expected message of type %T, got %T", *new(In), txData.Msg)
+		}
+		return fn(TxData[In]{
+			Hash: txData.Hash,
+			Msg:  in,
+			Tx:   txData.Tx,
+		})
+	}
+	return withMiddleware(handler, wCtx.getMessageMiddleware(t.group))
+}
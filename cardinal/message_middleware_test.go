@@ -0,0 +1,113 @@
+package cardinal_test
+
+import (
+	"errors"
+	"testing"
+
+	"pkg.world.dev/world-engine/assert"
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
+)
+
+type chargedMsg struct {
+	Amount int
+}
+
+type chargedResult struct {
+	Amount int
+}
+
+var errInsufficientFunds = errors.New("insufficient funds")
+
+func TestMessageMiddlewareWrapsEachMessage(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil)
+	world := tf.World
+	assert.NilError(t, cardinal.RegisterMessage[fooMessage, fooResponse](world, "foo"))
+	assert.NilError(t, cardinal.RegisterMessage[chargedMsg, chargedResult](world, "charged",
+		cardinal.WithCustomMessageGroup[chargedMsg, chargedResult]("shop")))
+
+	var calls []string
+	logMiddleware := func(next cardinal.MessageHandler) cardinal.MessageHandler {
+		return func(wCtx cardinal.WorldContext, msg types.Message, txData cardinal.TxData[any]) (any, error) {
+			calls = append(calls, "log:"+msg.FullName())
+			return next(wCtx, msg, txData)
+		}
+	}
+	// feeMiddleware rejects transactions from personas that cannot pay the fee.
+	feeMiddleware := func(next cardinal.MessageHandler) cardinal.MessageHandler {
+		return func(wCtx cardinal.WorldContext, msg types.Message, txData cardinal.TxData[any]) (any, error) {
+			calls = append(calls, "fee:"+txData.Tx.PersonaTag)
+			if txData.Tx.PersonaTag == "broke" {
+				return nil, errInsufficientFunds
+			}
+			return next(wCtx, msg, txData)
+		}
+	}
+	assert.NilError(t, cardinal.RegisterMessageMiddleware(world, logMiddleware))
+	assert.NilError(t, cardinal.RegisterMessageGroupMiddleware(world, "shop", feeMiddleware))
+
+	handled := map[string]int{}
+	err := cardinal.RegisterSystems(world, func(wCtx cardinal.WorldContext) error {
+		err := cardinal.EachMessage[fooMessage, fooResponse](wCtx,
+			func(tx cardinal.TxData[fooMessage]) (fooResponse, error) {
+				handled[tx.Tx.PersonaTag]++
+				return fooResponse{}, nil
+			})
+		if err != nil {
+			return err
+		}
+		return cardinal.EachMessage[chargedMsg, chargedResult](wCtx,
+			func(tx cardinal.TxData[chargedMsg]) (chargedResult, error) {
+				handled[tx.Tx.PersonaTag]++
+				return chargedResult{Amount: tx.Msg.Amount}, nil
+			})
+	})
+	assert.NilError(t, err)
+
+	fooMsg, ok := world.GetMessageByFullName("game.foo")
+	assert.True(t, ok)
+	chargedMsgType, ok := world.GetMessageByFullName("shop.charged")
+	assert.True(t, ok)
+
+	fooHash := tf.AddTransaction(fooMsg.ID(), fooMessage{}, &sign.Transaction{PersonaTag: "broke", Nonce: 1})
+	paidHash := tf.AddTransaction(chargedMsgType.ID(), chargedMsg{Amount: 5},
+		&sign.Transaction{PersonaTag: "rich", Nonce: 2})
+	rejectedHash := tf.AddTransaction(chargedMsgType.ID(), chargedMsg{Amount: 5},
+		&sign.Transaction{PersonaTag: "broke", Nonce: 3})
+	tf.DoTick()
+
+	// Middleware for all messages wraps the middleware for the group.
+	assert.DeepEqual(t, []string{"log:game.foo", "log:shop.charged", "fee:rich", "log:shop.charged", "fee:broke"},
+		calls)
+	// Messages outside the group are not charged, and rejected transactions never reach the handler.
+	assert.Equal(t, 1, handled["broke"])
+	assert.Equal(t, 1, handled["rich"])
+
+	receipts, err := world.GetTransactionReceiptsForTick(world.CurrentTick() - 1)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(receipts))
+	for _, r := range receipts {
+		switch r.TxHash {
+		case fooHash:
+			assert.Equal(t, 0, len(r.Errs))
+		case paidHash:
+			assert.Equal(t, 0, len(r.Errs))
+			assert.Equal(t, chargedResult{Amount: 5}, r.Result)
+		case rejectedHash:
+			assert.Equal(t, 1, len(r.Errs))
+			assert.ErrorIs(t, r.Errs[0], errInsufficientFunds)
+			assert.Equal(t, nil, r.Result)
+		default:
+			t.Fatalf("unexpected receipt for tx %s", r.TxHash)
+		}
+	}
+}
+
+func TestCannotRegisterMessageMiddlewareAfterWorldStarts(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil)
+	tf.StartWorld()
+	noop := func(next cardinal.MessageHandler) cardinal.MessageHandler { return next }
+	assert.IsError(t, cardinal.RegisterMessageMiddleware(tf.World, noop))
+	assert.IsError(t, cardinal.RegisterMessageGroupMiddleware(tf.World, "game", noop))
+}
@@ -67,11 +67,49 @@ func TestCopyTransactions(t *testing.T) {
 	txp.AddTransaction(1, FooMsg{X: 3}, &sign.Transaction{PersonaTag: "foo"})
 	txp.AddTransaction(2, FooMsg{X: 4}, &sign.Transaction{PersonaTag: "bar"})
 
-	copyTxp := txp.CopyTransactions(context.Background())
+	copyTxp := txp.CopyTransactions(context.Background(), 0)
 	assert.Equal(t, copyTxp.GetAmountOfTxs(), 2)
 	assert.Equal(t, txp.GetAmountOfTxs(), 0)
 }
 
+func TestCopyTransactionsHoldsDelayedTransactions(t *testing.T) {
+	type FooMsg struct {
+		X int
+	}
+	txp := txpool.New()
+	txp.AddTransaction(1, FooMsg{X: 3}, &sign.Transaction{PersonaTag: "foo"})
+	txp.AddTransaction(1, FooMsg{X: 4}, &sign.Transaction{PersonaTag: "bar", TargetTick: 2})
+
+	assert.Equal(t, len(txp.PendingTransactions()), 1)
+
+	copyTxp := txp.CopyTransactions(context.Background(), 1)
+	assert.Equal(t, copyTxp.GetAmountOfTxs(), 1)
+	assert.Equal(t, len(txp.PendingTransactions()), 1)
+
+	copyTxp = txp.CopyTransactions(context.Background(), 2)
+	assert.Equal(t, copyTxp.GetAmountOfTxs(), 1)
+	assert.Equal(t, copyTxp.ForID(1)[0].Msg, FooMsg{X: 4})
+	assert.Equal(t, len(txp.PendingTransactions()), 0)
+}
+
+func TestCopyTransactionsRemovesExpiredTransactions(t *testing.T) {
+	type FooMsg struct {
+		X int
+	}
+	txp := txpool.New()
+	txp.AddTransaction(1, FooMsg{X: 3}, &sign.Transaction{PersonaTag: "foo", ExpiryTick: 5})
+	txp.AddTransaction(1, FooMsg{X: 4}, &sign.Transaction{PersonaTag: "bar", ExpiryTick: 6})
+	txp.AddTransaction(1, FooMsg{X: 5}, &sign.Transaction{PersonaTag: "baz"})
+
+	copyTxp := txp.CopyTransactions(context.Background(), 6)
+	assert.Equal(t, copyTxp.GetAmountOfTxs(), 2)
+	assert.Equal(t, len(copyTxp.ForID(1)), 2)
+	expired := copyTxp.ExpiredTransactions()
+	assert.Equal(t, len(expired), 1)
+	assert.Equal(t, expired[0].Msg, FooMsg{X: 3})
+	assert.Equal(t, len(txp.ExpiredTransactions()), 0)
+}
+
 func TestMessageTypePanicsIfNoName(t *testing.T) {
 	type Foo struct{}
 	assert.Panics(
@@ -92,6 +130,36 @@ func TestMessageFullName(t *testing.T) {
 	assert.Equal(t, withGroup.FullName(), "bar.foo")
 }
 
+func TestMessageVersionsMustBeValid(t *testing.T) {
+	type Foo struct{ Bar int }
+	type OldFoo struct{}
+	adapt := func(OldFoo) (Foo, error) { return Foo{}, nil }
+	assert.Panics(t, func() {
+		NewMessageType[Foo, Foo]("foo", WithMsgVersion[Foo, Foo](0))
+	})
+	assert.Panics(t, func() {
+		NewMessageType[Foo, Foo]("foo", WithMsgPreviousVersion[OldFoo, Foo, Foo](1, adapt))
+	})
+	assert.Panics(t, func() {
+		NewMessageType[Foo, Foo]("foo",
+			WithMsgVersion[Foo, Foo](3),
+			WithMsgPreviousVersion[OldFoo, Foo, Foo](1, adapt),
+			WithMsgPreviousVersion[OldFoo, Foo, Foo](1, adapt))
+	})
+
+	msg := NewMessageType[Foo, Foo]("foo",
+		WithMsgPreviousVersion[OldFoo, Foo, Foo](1, func(OldFoo) (Foo, error) { return Foo{Bar: 1}, nil }),
+		WithMsgVersion[Foo, Foo](2))
+	assert.Equal(t, 2, msg.Version())
+	assert.Equal(t, 1, len(msg.PreviousVersions()))
+	previous := msg.PreviousVersions()[0]
+	assert.Equal(t, 1, previous.Version())
+	assert.Equal(t, msg.FullName(), previous.FullName())
+	decoded, err := previous.Decode([]byte("{}"))
+	assert.NilError(t, err)
+	assert.Equal(t, Foo{Bar: 1}, decoded)
+}
+
 func TestIsValidMessageText(t *testing.T) {
 	testCases := []struct {
 		testName       string
@@ -0,0 +1,79 @@
+package cardinal
+
+import (
+	"reflect"
+
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/codec"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+// defaultVersion is the version of messages and queries that are registered without a version.
+const defaultVersion = 1
+
+var _ types.Message = &previousMessageVersion[struct{}, struct{}, struct{}]{}
+
+// previousMessageVersion is an older version of a message that is still accepted by the server. Its input is converted
+// to the input of the latest version when it is decoded, so transactions of every version are added to the transaction
+// pool as the latest version, and systems only ever see the latest version.
+type previousMessageVersion[OldIn, In, Out any] struct {
+	*MessageType[In, Out]
+	version int
+	adapt   func(OldIn) (In, error)
+}
+
+func (v *previousMessageVersion[OldIn, In, Out]) Version() int {
+	return v.version
+}
+
+func (v *previousMessageVersion[OldIn, In, Out]) PreviousVersions() []types.Message {
+	return nil
+}
+
+// Decode decodes the input of this version and converts it to the input of the latest version.
+func (v *previousMessageVersion[OldIn, In, Out]) Decode(bytes []byte) (any, error) {
+	old, err := codec.Decode[OldIn](bytes)
+	if err != nil {
+		return nil, err
+	}
+	in, err := v.adapt(old)
+	if err != nil {
+		return nil, eris.Wrapf(err, "failed to convert version %d of message %q to version %d", v.version,
+			v.FullName(), v.MessageType.Version())
+	}
+	return in, nil
+}
+
+// IsEVMCompatible reports false, as only the latest version of a message can be sent from the EVM.
+func (v *previousMessageVersion[OldIn, In, Out]) IsEVMCompatible() bool {
+	return false
+}
+
+func (v *previousMessageVersion[OldIn, In, Out]) DecodeEVMBytes([]byte) (any, error) {
+	return nil, eris.Wrap(ErrEVMTypeNotSet, "")
+}
+
+// GetInFieldInformation returns a map of the fields of this version's input type and their types.
+func (v *previousMessageVersion[OldIn, In, Out]) GetInFieldInformation() map[string]any {
+	return types.GetFieldInformation(reflect.TypeOf(new(OldIn)).Elem())
+}
+
+// validateVersions checks that the given version is valid, and that the previous versions are older than it and
+// unique.
+func validateVersions(version int, previousVersions []int) error {
+	if version < defaultVersion {
+		return eris.Errorf("version must be at least %d, got %d", defaultVersion, version)
+	}
+	seen := make(map[int]bool, len(previousVersions))
+	for _, previous := range previousVersions {
+		if previous < defaultVersion || previous >= version {
+			return eris.Errorf("previous version %d must be between %d and %d", previous, defaultVersion, version-1)
+		}
+		if seen[previous] {
+			return eris.Errorf("previous version %d is registered more than once", previous)
+		}
+		seen[previous] = true
+	}
+	return nil
+}
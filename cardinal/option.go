@@ -30,6 +30,14 @@ func WithPort(port string) WorldOption {
 	}
 }
 
+// WithGRPCPort enables the gRPC API of the world on the given port. The gRPC API mirrors the transaction, query, world
+// and receipt routes of the HTTP server, and streams the results of each tick.
+func WithGRPCPort(port string) WorldOption {
+	return WorldOption{
+		serverOption: server.WithGRPCPort(port),
+	}
+}
+
 // WithReceiptHistorySize specifies how many ticks worth of transaction receipts should be kept in memory. The default
 // is 10. A smaller number uses less memory, but limits the amount of historical receipts available.
 func WithReceiptHistorySize(size int) WorldOption {
@@ -40,6 +48,46 @@ func WithReceiptHistorySize(size int) WorldOption {
 	}
 }
 
+// WithReceiptRetention specifies how many ticks worth of transaction receipts should be persisted to storage. Persisted
+// receipts survive restarts and can be looked up by transaction hash. Set to 0 to only keep receipts in memory.
+func WithReceiptRetention(ticks uint64) WorldOption {
+	return WorldOption{
+		cardinalOption: func(world *World) {
+			world.receiptRetention = ticks
+		},
+	}
+}
+
+// WithEventRetention specifies how many ticks worth of broadcast events should be persisted to storage, so that
+// clients can catch up on the events they missed. Set to 0 to not keep any event history.
+func WithEventRetention(ticks uint64) WorldOption {
+	return WorldOption{
+		cardinalOption: func(world *World) {
+			world.eventRetention = ticks
+		},
+	}
+}
+
+// WithAdminPersonas gives the admin role to the given persona tags, allowing them to grant and revoke the roles of
+// other personas. The admin role of these personas cannot be revoked.
+func WithAdminPersonas(personaTags ...string) WorldOption {
+	return WorldOption{
+		cardinalOption: func(world *World) {
+			world.adminPersonas = append(world.adminPersonas, personaTags...)
+		},
+	}
+}
+
+// WithSystemSignerAddress sets the address that signs the system transactions of system-only messages, overriding
+// CARDINAL_SYSTEM_SIGNER_ADDRESS.
+func WithSystemSignerAddress(address string) WorldOption {
+	return WorldOption{
+		cardinalOption: func(world *World) {
+			world.systemSignerAddress = address
+		},
+	}
+}
+
 // WithDisableSignatureVerification disables signature verification for the HTTP server. This should only be
 // used for local development.
 func WithDisableSignatureVerification() WorldOption {
@@ -48,6 +96,39 @@ func WithDisableSignatureVerification() WorldOption {
 	}
 }
 
+// WithDebugAPIKey sets the API key that authenticates requests to the debug routes, /debug/state and /cql, overriding
+// CARDINAL_DEBUG_API_KEY. If neither an API key nor a debug signer address is set, the debug routes are open to anyone,
+// unless rollup mode is enabled, in which case they are disabled.
+func WithDebugAPIKey(key string) WorldOption {
+	return WorldOption{
+		serverOption: server.WithDebugAPIKey(key),
+	}
+}
+
+// WithDebugSignerAddress sets the address that can sign requests to the debug routes, overriding
+// CARDINAL_DEBUG_SIGNER_ADDRESS.
+func WithDebugSignerAddress(address string) WorldOption {
+	return WorldOption{
+		serverOption: server.WithDebugSignerAddress(address),
+	}
+}
+
+// WithDisableDebugRoutes removes the debug routes from the HTTP and gRPC servers.
+func WithDisableDebugRoutes() WorldOption {
+	return WorldOption{
+		serverOption: server.DisableDebugRoutes(),
+	}
+}
+
+// WithMetrics serves Prometheus metrics on /metrics, like TELEMETRY_METRICS_ENABLED does.
+func WithMetrics() WorldOption {
+	return WorldOption{
+		cardinalOption: func(world *World) {
+			world.enableMetrics()
+		},
+	}
+}
+
 // WithTickChannel sets the channel that will be used to decide when world.doTick is executed. If unset, a loop interval
 // of 1 second will be set. To set some other time, use: WithTickChannel(time.Tick(<some-duration>)). Tests can pass
 // in a channel controlled by the test for fine-grained control over when ticks are executed.
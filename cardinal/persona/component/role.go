@@ -0,0 +1,18 @@
+package component
+
+import "slices"
+
+// RoleComponent holds the roles that have been granted to a persona. It is added to the persona's entity the first
+// time a role is granted to it.
+type RoleComponent struct {
+	Roles []string
+}
+
+func (RoleComponent) Name() string {
+	return "RoleComponent"
+}
+
+// HasRole reports whether the given role has been granted.
+func (r RoleComponent) HasRole(role string) bool {
+	return slices.Contains(r.Roles, role)
+}
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrPersonaTagHasNoSigner        = errors.New("persona tag does not have a signer")
 	ErrCreatePersonaTxsNotProcessed = errors.New("create persona txs have not been processed for the given tick")
+	ErrPersonaNotAuthorized         = errors.New("persona is not authorized to send this message")
 )
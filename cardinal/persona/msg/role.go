@@ -0,0 +1,26 @@
+package msg
+
+var (
+	GrantRoleMessageName  = "grant-role"
+	RevokeRoleMessageName = "revoke-role"
+)
+
+// GrantRole grants a role to a persona. It can only be sent by personas with the admin role.
+type GrantRole struct {
+	PersonaTag string `json:"personaTag"`
+	Role       string `json:"role"`
+}
+
+type GrantRoleResult struct {
+	Success bool `json:"success"`
+}
+
+// RevokeRole revokes a role from a persona. It can only be sent by personas with the admin role.
+type RevokeRole struct {
+	PersonaTag string `json:"personaTag"`
+	Role       string `json:"role"`
+}
+
+type RevokeRoleResult struct {
+	Success bool `json:"success"`
+}
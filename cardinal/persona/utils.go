@@ -7,6 +7,9 @@ import (
 const (
 	MinimumPersonaTagLength = 3
 	MaximumPersonaTagLength = 16
+
+	// AdminRole is the role required to grant and revoke the roles of personas.
+	AdminRole = "admin"
 )
 
 var (
@@ -2,6 +2,7 @@ package cardinal
 
 import (
 	"errors"
+	"slices"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +13,7 @@ import (
 	"pkg.world.dev/world-engine/cardinal/persona/component"
 	"pkg.world.dev/world-engine/cardinal/persona/msg"
 	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
 )
 
 var (
@@ -76,7 +78,7 @@ func (p *personaPlugin) RegisterQueries(world *World) error {
 }
 
 func (p *personaPlugin) RegisterSystems(world *World) error {
-	err := RegisterSystems(world, createPersonaSystem, authorizePersonaAddressSystem)
+	err := RegisterSystems(world, createPersonaSystem, authorizePersonaAddressSystem, grantRoleSystem, revokeRoleSystem)
 	if err != nil {
 		return err
 	}
@@ -84,11 +86,10 @@ func (p *personaPlugin) RegisterSystems(world *World) error {
 }
 
 func (p *personaPlugin) RegisterComponents(world *World) error {
-	err := RegisterComponent[component.SignerComponent](world)
-	if err != nil {
-		return err
-	}
-	return nil
+	return errors.Join(
+		RegisterComponent[component.SignerComponent](world),
+		RegisterComponent[component.RoleComponent](world),
+	)
 }
 
 func (p *personaPlugin) RegisterMessages(world *World) error {
@@ -101,7 +102,18 @@ func (p *personaPlugin) RegisterMessages(world *World) error {
 		RegisterMessage[msg.AuthorizePersonaAddress, msg.AuthorizePersonaAddressResult](
 			world,
 			"authorize-persona-address",
-		))
+		),
+		RegisterMessage[msg.GrantRole, msg.GrantRoleResult](
+			world,
+			msg.GrantRoleMessageName,
+			WithCustomMessageGroup[msg.GrantRole, msg.GrantRoleResult]("persona"),
+			WithMsgRequiredRoles[msg.GrantRole, msg.GrantRoleResult](persona.AdminRole)),
+		RegisterMessage[msg.RevokeRole, msg.RevokeRoleResult](
+			world,
+			msg.RevokeRoleMessageName,
+			WithCustomMessageGroup[msg.RevokeRole, msg.RevokeRoleResult]("persona"),
+			WithMsgRequiredRoles[msg.RevokeRole, msg.RevokeRoleResult](persona.AdminRole)),
+	)
 }
 
 // -----------------------------------------------------------------------------
@@ -125,7 +137,7 @@ func authorizePersonaAddressSystem(wCtx WorldContext) error {
 
 			// Check if the Persona Tag exists
 			lowerPersona := strings.ToLower(tx.PersonaTag)
-			data, ok := globalPersonaTagToAddressIndex[lowerPersona]
+			data, ok := wCtx.personaIndex()[lowerPersona]
 			if !ok {
 				return result, eris.Errorf("persona %s does not exist", tx.PersonaTag)
 			}
@@ -158,6 +170,78 @@ func authorizePersonaAddressSystem(wCtx WorldContext) error {
 	)
 }
 
+// grantRoleSystem grants roles to personas. Only personas with the admin role can send the grant-role message.
+func grantRoleSystem(wCtx WorldContext) error {
+	if err := buildGlobalPersonaIndex(wCtx); err != nil {
+		return err
+	}
+	return EachMessage[msg.GrantRole, msg.GrantRoleResult](
+		wCtx,
+		func(txData TxData[msg.GrantRole]) (result msg.GrantRoleResult, err error) {
+			txMsg := txData.Msg
+			if txMsg.Role == "" {
+				return result, eris.New("role must not be empty")
+			}
+			err = updatePersonaRoles(wCtx, txMsg.PersonaTag, func(roles *component.RoleComponent) {
+				if !roles.HasRole(txMsg.Role) {
+					roles.Roles = append(roles.Roles, txMsg.Role)
+				}
+			})
+			if err != nil {
+				return result, err
+			}
+			result.Success = true
+			return result, nil
+		},
+	)
+}
+
+// revokeRoleSystem revokes roles from personas. Only personas with the admin role can send the revoke-role message.
+func revokeRoleSystem(wCtx WorldContext) error {
+	if err := buildGlobalPersonaIndex(wCtx); err != nil {
+		return err
+	}
+	return EachMessage[msg.RevokeRole, msg.RevokeRoleResult](
+		wCtx,
+		func(txData TxData[msg.RevokeRole]) (result msg.RevokeRoleResult, err error) {
+			txMsg := txData.Msg
+			err = updatePersonaRoles(wCtx, txMsg.PersonaTag, func(roles *component.RoleComponent) {
+				roles.Roles = slices.DeleteFunc(roles.Roles, func(role string) bool {
+					return role == txMsg.Role
+				})
+			})
+			if err != nil {
+				return result, err
+			}
+			result.Success = true
+			return result, nil
+		},
+	)
+}
+
+// updatePersonaRoles applies fn to the roles of the given persona tag. The RoleComponent is added to the persona's
+// entity if no role has been granted to it before.
+func updatePersonaRoles(wCtx WorldContext, personaTag string, fn func(*component.RoleComponent)) error {
+	data, ok := wCtx.personaIndex()[strings.ToLower(personaTag)]
+	if !ok {
+		return eris.Errorf("persona %s does not exist", personaTag)
+	}
+	roles, err := GetComponent[component.RoleComponent](wCtx, data.EntityID)
+	if eris.Is(err, ErrComponentNotOnEntity) {
+		if err = AddComponentTo[component.RoleComponent](wCtx, data.EntityID); err != nil {
+			return eris.Wrap(err, "unable to add role component to persona")
+		}
+		roles = &component.RoleComponent{Roles: nil}
+	} else if err != nil {
+		return eris.Wrap(err, "unable to get role component of persona")
+	}
+	fn(roles)
+	if err = SetComponent[component.RoleComponent](wCtx, data.EntityID, roles); err != nil {
+		return eris.Wrap(err, "unable to update role component of persona")
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // Persona System
 // -----------------------------------------------------------------------------
@@ -183,9 +267,14 @@ func createPersonaSystem(wCtx WorldContext) error {
 				return result, err
 			}
 
+			// The system persona tag is reserved for system transactions.
+			if strings.EqualFold(txMsg.PersonaTag, sign.SystemPersonaTag) {
+				return result, eris.Errorf("persona tag %q is reserved", txMsg.PersonaTag)
+			}
+
 			// Temporarily convert tag to lowercase to check against mapping of lowercase tags
 			lowerPersona := strings.ToLower(txMsg.PersonaTag)
-			if _, ok := globalPersonaTagToAddressIndex[lowerPersona]; ok {
+			if _, ok := wCtx.personaIndex()[lowerPersona]; ok {
 				// This PersonaTag has already been registered. Don't do anything
 				err = eris.Errorf("persona tag %s has already been registered", txMsg.PersonaTag)
 				return result, err
@@ -203,7 +292,7 @@ func createPersonaSystem(wCtx WorldContext) error {
 			); err != nil {
 				return result, eris.Wrap(err, "")
 			}
-			globalPersonaTagToAddressIndex[lowerPersona] = personaIndexEntry{
+			wCtx.personaIndex()[lowerPersona] = personaIndexEntry{
 				SignerAddress: txMsg.SignerAddress,
 				EntityID:      id,
 			}
@@ -218,6 +307,10 @@ func createPersonaSystem(wCtx WorldContext) error {
 // -----------------------------------------------------------------------------
 
 func buildGlobalPersonaIndex(wCtx WorldContext) error {
+	// Simulations use a copy of the index, which is discarded along with the rest of the simulation.
+	if wCtx.isSimulation() {
+		return nil
+	}
 	// Rebuild the index if we haven't built it yet OR if we're in test and the CurrentTick has been reset.
 	if globalPersonaTagToAddressIndex != nil && tickOfPersonaTagToAddressIndex < wCtx.CurrentTick() {
 		return nil
@@ -225,7 +318,7 @@ func buildGlobalPersonaIndex(wCtx WorldContext) error {
 	tickOfPersonaTagToAddressIndex = wCtx.CurrentTick()
 	globalPersonaTagToAddressIndex = map[string]personaIndexEntry{}
 	var errs []error
-	s := NewSearch().Entity(filter.Exact(filter.Component[component.SignerComponent]()))
+	s := NewSearch().Entity(filter.Contains(filter.Component[component.SignerComponent]()))
 	err := s.Each(wCtx,
 		func(id types.EntityID) bool {
 			sc, err := GetComponent[component.SignerComponent](wCtx, id)
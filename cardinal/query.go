@@ -20,6 +20,10 @@ type query interface {
 	Name() string
 	// Group returns the group of the query.
 	Group() string
+	// Version returns the version of the query's request and reply types.
+	Version() int
+	// PreviousVersions returns the older versions of the query that are still served.
+	PreviousVersions() []query
 	// IsEVMCompatible reports if the query is able to be sent from the EVM.
 	IsEVMCompatible() bool
 	// GetRequestFieldInformation returns a map of the fields of the query's request type and their types.
@@ -45,6 +49,10 @@ type queryType[Request any, Reply any] struct {
 	handler    func(wCtx WorldContext, req *Request) (*Reply, error)
 	requestABI *ethereumAbi.Type
 	replyABI   *ethereumAbi.Type
+	version    int
+	// previousVersions build the older versions of the query. They are built when they are needed so that they share
+	// the name and group of this query regardless of the order the options are applied in.
+	previousVersions []func() query
 }
 
 func WithQueryEVMSupport[Request, Reply any]() QueryOption[Request, Reply] {
@@ -65,6 +73,54 @@ func WithCustomQueryGroup[Request, Reply any](group string) QueryOption[Request,
 	}
 }
 
+// WithQueryVersion sets the version of the query's request and reply types. Versions start at 1, and should be
+// incremented each time the request or reply type changes in a way that breaks existing clients. The latest version of
+// a query is served under both /query/<group>/<name> and /query/<group>/v<version>/<name>.
+func WithQueryVersion[Request, Reply any](version int) QueryOption[Request, Reply] {
+	return func(qt *queryType[Request, Reply]) {
+		qt.version = version
+	}
+}
+
+// WithQueryPreviousVersion keeps serving an older version of the query under /query/<group>/v<version>/<name>.
+// Requests to the older version are converted to the latest request type with adaptRequest and handled by the latest
+// version, and its reply is converted back to the older reply type with adaptReply.
+func WithQueryPreviousVersion[OldRequest, OldReply, Request, Reply any](
+	version int,
+	adaptRequest func(OldRequest) (Request, error),
+	adaptReply func(Reply) (OldReply, error),
+) QueryOption[Request, Reply] {
+	return func(qt *queryType[Request, Reply]) {
+		qt.previousVersions = append(qt.previousVersions, func() query {
+			return &queryType[OldRequest, OldReply]{
+				name:    qt.name,
+				group:   qt.group,
+				version: version,
+				handler: func(wCtx WorldContext, oldReq *OldRequest) (*OldReply, error) {
+					req, err := adaptRequest(*oldReq)
+					if err != nil {
+						return nil, eris.Wrapf(err, "failed to convert version %d of query %s/%s request to version %d",
+							version, qt.group, qt.name, qt.version)
+					}
+					reply, err := qt.handler(wCtx, &req)
+					if err != nil {
+						return nil, err
+					}
+					if reply == nil {
+						return nil, eris.Errorf("query %s/%s returned no reply", qt.group, qt.name)
+					}
+					oldReply, err := adaptReply(*reply)
+					if err != nil {
+						return nil, eris.Wrapf(err, "failed to convert version %d of query %s/%s reply to version %d",
+							qt.version, qt.group, qt.name, version)
+					}
+					return &oldReply, nil
+				},
+			}
+		})
+	}
+}
+
 func newQueryType[Request any, Reply any](
 	name string,
 	handler func(wCtx WorldContext, req *Request) (*Reply, error),
@@ -78,10 +134,18 @@ func newQueryType[Request any, Reply any](
 		name:    name,
 		group:   DefaultQueryGroup,
 		handler: handler,
+		version: defaultVersion,
 	}
 	for _, opt := range opts {
 		opt(r)
 	}
+	previousVersions := make([]int, 0, len(r.previousVersions))
+	for _, previous := range r.PreviousVersions() {
+		previousVersions = append(previousVersions, previous.Version())
+	}
+	if err := validateVersions(r.version, previousVersions); err != nil {
+		return nil, eris.Wrapf(err, "invalid query: %s", name)
+	}
 
 	return r, nil
 }
@@ -118,6 +182,18 @@ func (r *queryType[Request, Reply]) Group() string {
 	return r.group
 }
 
+func (r *queryType[Request, Reply]) Version() int {
+	return r.version
+}
+
+func (r *queryType[Request, Reply]) PreviousVersions() []query {
+	previousVersions := make([]query, 0, len(r.previousVersions))
+	for _, build := range r.previousVersions {
+		previousVersions = append(previousVersions, build())
+	}
+	return previousVersions
+}
+
 func (r *queryType[Request, Reply]) handleQuery(wCtx WorldContext, a any) (any, error) {
 	var request *Request
 	if reflect.TypeOf(a).Kind() == reflect.Pointer {
@@ -13,6 +13,7 @@ type QueryManager interface {
 	RegisterQuery(queryInput query) error
 	GetRegisteredQueries() []query
 	HandleQuery(group string, name string, bz []byte) ([]byte, error)
+	HandleVersionedQuery(group string, name string, version int, bz []byte) ([]byte, error)
 	HandleQueryEVM(group string, name string, abiRequest []byte) ([]byte, error)
 	getQuery(group string, name string) (query, error)
 	BuildQueryFields() []types.FieldDetail
@@ -21,12 +22,21 @@ type QueryManager interface {
 type queryManager struct {
 	world                    *World
 	registeredQueriesByGroup map[string]map[string]query // group:name:query
+	// previousQueryVersions holds the older versions of the registered queries that are still served.
+	previousQueryVersions map[queryVersionKey]query
+}
+
+type queryVersionKey struct {
+	group   string
+	name    string
+	version int
 }
 
 func newQueryManager(world *World) QueryManager {
 	return &queryManager{
 		world:                    world,
 		registeredQueriesByGroup: make(map[string]map[string]query),
+		previousQueryVersions:    make(map[queryVersionKey]query),
 	}
 }
 
@@ -39,6 +49,9 @@ func (m *queryManager) RegisterQuery(queryInput query) error {
 	}
 
 	m.registeredQueriesByGroup[queryInput.Group()][queryInput.Name()] = queryInput
+	for _, previous := range queryInput.PreviousVersions() {
+		m.previousQueryVersions[queryVersionKey{previous.Group(), previous.Name(), previous.Version()}] = previous
+	}
 	return nil
 }
 
@@ -61,6 +74,15 @@ func (m *queryManager) HandleQuery(group string, name string, bz []byte) ([]byte
 	return q.handleQueryJSON(NewReadOnlyWorldContext(m.world), bz)
 }
 
+// HandleVersionedQuery handles a json-encoded request for the given version of a query.
+func (m *queryManager) HandleVersionedQuery(group string, name string, version int, bz []byte) ([]byte, error) {
+	q, err := m.getQueryVersion(group, name, version)
+	if err != nil {
+		return nil, eris.Wrapf(err, "unable to find query %s/v%d/%s", group, version, name)
+	}
+	return q.handleQueryJSON(NewReadOnlyWorldContext(m.world), bz)
+}
+
 func (m *queryManager) HandleQueryEVM(group string, name string, abiRequest []byte) ([]byte, error) {
 	q, err := m.getQuery(group, name)
 	if err != nil {
@@ -78,16 +100,41 @@ func (m *queryManager) getQuery(group string, name string) (query, error) {
 	return query, nil
 }
 
+// getQueryVersion returns the given version of a query, which may be its latest version.
+func (m *queryManager) getQueryVersion(group string, name string, version int) (query, error) {
+	latest, err := m.getQuery(group, name)
+	if err != nil {
+		return nil, err
+	}
+	if latest.Version() == version {
+		return latest, nil
+	}
+	q, ok := m.previousQueryVersions[queryVersionKey{group, name, version}]
+	if !ok {
+		return nil, types.ErrQueryNotFound
+	}
+	return q, nil
+}
+
 func (m *queryManager) BuildQueryFields() []types.FieldDetail {
 	// Collecting the structure of all queries
 	queries := m.GetRegisteredQueries()
-	queriesFields := make([]types.FieldDetail, 0, len(queries))
+	queriesFields := make([]types.FieldDetail, 0, len(queries)+len(m.previousQueryVersions))
 	for _, q := range queries {
 		// Extracting the fields of the q
 		queriesFields = append(queriesFields, types.FieldDetail{
-			Name:   q.Name(),
-			Fields: q.GetRequestFieldInformation(),
-			URL:    utils.GetQueryURL(q.Group(), q.Name()),
+			Name:    q.Name(),
+			Fields:  q.GetRequestFieldInformation(),
+			URL:     utils.GetQueryURL(q.Group(), q.Name()),
+			Version: q.Version(),
+		})
+	}
+	for _, q := range m.previousQueryVersions {
+		queriesFields = append(queriesFields, types.FieldDetail{
+			Name:    q.Name(),
+			Fields:  q.GetRequestFieldInformation(),
+			URL:     utils.GetVersionedQueryURL(q.Group(), q.Version(), q.Name()),
+			Version: q.Version(),
 		})
 	}
 	return queriesFields
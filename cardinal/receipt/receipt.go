@@ -14,22 +14,28 @@ import (
 var (
 	ErrTickHasNotBeenProcessed = eris.New("tick is still in progress")
 	ErrOldTickHasBeenDiscarded = eris.New("the requested tick has been discarded due to age")
+	ErrReceiptNotFound         = eris.New("receipt not found")
 )
 
 // History keeps track of transaction "receipts" (the result of a transaction and any associated errors) for some number
 // of ticks.
 type History struct {
-	currTick     *atomic.Uint64
+	currTick *atomic.Uint64
+	// firstTick is the tick the history started at. Receipts from earlier ticks were never recorded by this history.
+	firstTick    *atomic.Uint64
 	ticksToStore uint64
 	// Receipts for a given tick are assigned to an index into this history slice which acts as a ring buffer.
 	history []map[types.TxHash]Receipt
 }
 
-// Receipt contains a transaction hash, an arbitrary result, and a list of errors.
+// Receipt contains a transaction hash, an arbitrary result, and a list of errors. PersonaTag and MsgName identify the
+// persona that sent the transaction and the full name of the message it contained.
 type Receipt struct {
-	TxHash types.TxHash
-	Result any
-	Errs   []error
+	TxHash     types.TxHash
+	PersonaTag string
+	MsgName    string
+	Result     any
+	Errs       []error
 }
 
 func (r Receipt) MarshalJSON() ([]byte, error) {
@@ -39,13 +45,17 @@ func (r Receipt) MarshalJSON() ([]byte, error) {
 	}
 
 	return codec.Encode(struct {
-		TxHash types.TxHash `json:"txHash"`
-		Result any          `json:"result"`
-		Errs   []string     `json:"errors"`
+		TxHash     types.TxHash `json:"txHash"`
+		PersonaTag string       `json:"personaTag,omitempty"`
+		MsgName    string       `json:"msgName,omitempty"`
+		Result     any          `json:"result"`
+		Errs       []string     `json:"errors"`
 	}{
-		TxHash: r.TxHash,
-		Result: r.Result,
-		Errs:   errStrings,
+		TxHash:     r.TxHash,
+		PersonaTag: r.PersonaTag,
+		MsgName:    r.MsgName,
+		Result:     r.Result,
+		Errs:       errStrings,
 	})
 }
 
@@ -54,7 +64,8 @@ func NewHistory(currentTick uint64, ticksToStore int) *History {
 	// Add an extra tick for the "current" tick.
 	ticksToStore++
 	h := &History{
-		currTick: &atomic.Uint64{},
+		currTick:  &atomic.Uint64{},
+		firstTick: &atomic.Uint64{},
 		// Store ticksToStore plus the "current" tick
 		ticksToStore: uint64(ticksToStore),
 	}
@@ -63,6 +74,7 @@ func NewHistory(currentTick uint64, ticksToStore int) *History {
 		h.history = append(h.history, map[types.TxHash]Receipt{})
 	}
 	h.currTick.Store(currentTick)
+	h.firstTick.Store(currentTick)
 	return h
 }
 
@@ -78,8 +90,10 @@ func (h *History) NextTick() {
 	h.history[mod] = map[types.TxHash]Receipt{}
 }
 
+// SetTick sets the current tick of the history. Receipts from ticks before the given tick are treated as discarded.
 func (h *History) SetTick(tick uint64) {
 	h.currTick.Store(tick)
+	h.firstTick.Store(tick)
 }
 
 // AddError associates the given error with the given transaction hash. Calling this multiple times will append
@@ -102,6 +116,19 @@ func (h *History) SetResult(hash types.TxHash, result any) {
 	h.history[tick][hash] = rec
 }
 
+// SetTxInfo records the persona tag and message name of the transaction with the given hash on its receipt in the
+// current tick. Nothing is recorded if the transaction does not have a receipt.
+func (h *History) SetTxInfo(hash types.TxHash, personaTag, msgName string) {
+	tick := int(h.currTick.Load() % h.ticksToStore)
+	rec, ok := h.history[tick][hash]
+	if !ok {
+		return
+	}
+	rec.PersonaTag = personaTag
+	rec.MsgName = msgName
+	h.history[tick][hash] = rec
+}
+
 // GetReceipt gets the receipt (the transaction result and the list of errors) for the given transaction hash in the
 // current tick. To get receipts from previous ticks use GetReceiptsForTick.
 func (h *History) GetReceipt(hash types.TxHash) (Receipt, bool) {
@@ -119,7 +146,7 @@ func (h *History) GetReceiptsForTick(tick uint64) ([]Receipt, error) {
 	if currTick <= tick {
 		return nil, ErrTickHasNotBeenProcessed
 	}
-	if currTick-tick >= h.ticksToStore {
+	if currTick-tick >= h.ticksToStore || tick < h.firstTick.Load() {
 		return nil, ErrOldTickHasBeenDiscarded
 	}
 	mod := tick % h.ticksToStore
@@ -130,3 +157,16 @@ func (h *History) GetReceiptsForTick(tick uint64) ([]Receipt, error) {
 
 	return recs, nil
 }
+
+// FindReceipt searches the ticks that have been processed and are still stored for the receipt of the given
+// transaction hash. The tick the receipt was produced in is returned along with the receipt.
+func (h *History) FindReceipt(hash types.TxHash) (rec Receipt, tick uint64, ok bool) {
+	currTick := h.currTick.Load()
+	for i := uint64(1); i < h.ticksToStore && i <= currTick-h.firstTick.Load(); i++ {
+		tick = currTick - i
+		if rec, ok = h.history[tick%h.ticksToStore][hash]; ok {
+			return rec, tick, true
+		}
+	}
+	return Receipt{}, 0, false
+}
@@ -170,3 +170,28 @@ func TestReceipt_ReceiptErrorsArePresentInJSON(t *testing.T) {
 	assert.Contains(t, body, receiptResult)
 	assert.Contains(t, body, receiptError)
 }
+
+func TestCanFindReceiptInPreviousTicks(t *testing.T) {
+	rh := NewHistory(10, 3)
+	hash := txHash(t)
+	rh.SetResult(hash, "some-result")
+	rh.SetTxInfo(hash, "some-persona", "game.some-message")
+
+	// Receipts for the current tick are not available until the tick has been processed.
+	_, _, ok := rh.FindReceipt(hash)
+	assert.Check(t, !ok)
+
+	rh.NextTick()
+	rh.NextTick()
+	rec, tick, ok := rh.FindReceipt(hash)
+	assert.Check(t, ok)
+	assert.Equal(t, uint64(10), tick)
+	assert.Equal(t, "some-result", rec.Result)
+	assert.Equal(t, "some-persona", rec.PersonaTag)
+	assert.Equal(t, "game.some-message", rec.MsgName)
+
+	rh.NextTick()
+	rh.NextTick()
+	_, _, ok = rh.FindReceipt(hash)
+	assert.Check(t, !ok)
+}
@@ -101,7 +101,7 @@ OuterLoop:
 				if err != nil {
 					return eris.Wrap(err, "failed to unmarshal transaction data")
 				}
-				msgValue, err := msgType.Decode(protoTx.GetBody())
+				msgValue, err := decodeMessage(msgType, protoTx)
 				if err != nil {
 					return err
 				}
@@ -123,6 +123,21 @@ OuterLoop:
 	return nil
 }
 
+// decodeMessage decodes the message in the body of the transaction with the version of the message the body was
+// encoded with. Older versions are converted to the latest version when they are decoded.
+func decodeMessage(msgType types.Message, tx *shard.Transaction) (any, error) {
+	version := int(tx.GetMessageVersion())
+	if version == 0 || version == msgType.Version() {
+		return msgType.Decode(tx.GetBody())
+	}
+	for _, previous := range msgType.PreviousVersions() {
+		if previous.Version() == version {
+			return previous.Decode(tx.GetBody())
+		}
+	}
+	return nil, eris.Errorf("version %d of message %q does not exist in Cardinal", version, msgType.FullName())
+}
+
 func protoTxToSignTx(t *shard.Transaction) *sign.Transaction {
 	tx := &sign.Transaction{
 		PersonaTag: t.GetPersonaTag(),
@@ -131,6 +146,8 @@ func protoTxToSignTx(t *shard.Transaction) *sign.Transaction {
 		Signature:  t.GetSignature(),
 		Hash:       common.Hash{},
 		Body:       t.GetBody(),
+		TargetTick: t.GetTargetTick(),
+		ExpiryTick: t.GetExpiryTick(),
 	}
 	// HashHex will populate the hash.
 	tx.HashHex()
@@ -3,6 +3,7 @@ package iterator_test
 import (
 	"context"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -14,6 +15,7 @@ import (
 	"pkg.world.dev/world-engine/cardinal/router/iterator"
 	"pkg.world.dev/world-engine/cardinal/types"
 	shard "pkg.world.dev/world-engine/rift/shard/v2"
+	"pkg.world.dev/world-engine/sign"
 )
 
 var _ shard.TransactionHandlerClient = &mockQuerier{}
@@ -105,6 +107,17 @@ func TestIteratorHappyPath(t *testing.T) {
 		Nonce:      1,
 		Signature:  "fo",
 		Body:       msgBytes,
+		TargetTick: 12,
+		ExpiryTick: 20,
+	}
+	wantTx := &sign.Transaction{
+		PersonaTag: protoTx.GetPersonaTag(),
+		Namespace:  namespace,
+		Nonce:      protoTx.GetNonce(),
+		Signature:  protoTx.GetSignature(),
+		Body:       msgBytes,
+		TargetTick: protoTx.GetTargetTick(),
+		ExpiryTick: protoTx.GetExpiryTick(),
 	}
 	txBz, err := proto.Marshal(protoTx)
 	assert.NilError(t, err)
@@ -149,10 +162,97 @@ func TestIteratorHappyPath(t *testing.T) {
 		assert.True(t, len(tx.Tx.Hash.Bytes()) > 1)
 		assert.Equal(t, tx.Tx.Namespace, namespace)
 		assert.DeepEqual(t, []byte(tx.Tx.Body), msgBytes)
+		assert.Equal(t, tx.Tx.TargetTick, protoTx.GetTargetTick())
+		assert.Equal(t, tx.Tx.ExpiryTick, protoTx.GetExpiryTick())
+		// The recovered transaction has the same hash as the transaction that was submitted.
+		assert.Equal(t, tx.Tx.HashHex(), wantTx.HashHex())
+
+		return nil
+	})
+	assert.NilError(t, err)
+}
+
+func TestIteratorDecodesTheMessageVersionOfTheTransaction(t *testing.T) {
+	type fooInV1 struct{ Y int }
+	versionedMsg := cardinal.NewMessageType[fooIn, fooOut]("versioned",
+		cardinal.WithMsgVersion[fooIn, fooOut](2),
+		cardinal.WithMsgPreviousVersion[fooInV1, fooIn, fooOut](1, func(v1 fooInV1) (fooIn, error) {
+			return fooIn{X: v1.Y * 10}, nil
+		}),
+	)
+	assert.NilError(t, versionedMsg.SetID(11))
+	namespace := "ns"
+	v1Bytes, err := json.Marshal(fooInV1{Y: 4})
+	assert.NilError(t, err)
+	v2Bytes, err := versionedMsg.Encode(fooIn{X: 7})
+	assert.NilError(t, err)
 
+	txData := func(body []byte, version uint32) *shard.TxData {
+		txBz, err := proto.Marshal(&shard.Transaction{
+			PersonaTag:     "ty",
+			Namespace:      namespace,
+			Nonce:          1,
+			Signature:      "fo",
+			Body:           body,
+			MessageVersion: version,
+		})
+		assert.NilError(t, err)
+		return &shard.TxData{TxId: uint64(versionedMsg.ID()), GameShardTransaction: txBz}
+	}
+	querier := &mockQuerier{
+		ret: []*shard.QueryTransactionsResponse{
+			{
+				Epochs: []*shard.Epoch{
+					{
+						Epoch: 12,
+						Txs: []*shard.TxData{
+							txData(v1Bytes, 1),
+							txData(v2Bytes, 2),
+							// Transactions submitted before the version was recorded are decoded as the latest version.
+							txData(v2Bytes, 0),
+						},
+					},
+				},
+				Page: &shard.PageResponse{},
+			},
+		},
+	}
+	it := iterator.New(
+		func(id types.MessageID) (types.Message, bool) {
+			if id == versionedMsg.ID() {
+				return versionedMsg, true
+			}
+			return nil, false
+		},
+		namespace,
+		querier,
+	)
+	err = it.Each(func(batch []*iterator.TxBatch, _, _ uint64) error {
+		assert.Len(t, batch, 3)
+		assert.Equal(t, batch[0].MsgValue, fooIn{X: 40})
+		// The body is kept as it was submitted, so the hash of the transaction does not change.
+		assert.DeepEqual(t, []byte(batch[0].Tx.Body), v1Bytes)
+		assert.Equal(t, batch[1].MsgValue, fooIn{X: 7})
+		assert.Equal(t, batch[2].MsgValue, fooIn{X: 7})
 		return nil
 	})
 	assert.NilError(t, err)
+
+	querier = &mockQuerier{
+		ret: []*shard.QueryTransactionsResponse{
+			{
+				Epochs: []*shard.Epoch{{Epoch: 12, Txs: []*shard.TxData{txData(v1Bytes, 3)}}},
+				Page:   &shard.PageResponse{},
+			},
+		},
+	}
+	it = iterator.New(
+		func(types.MessageID) (types.Message, bool) { return versionedMsg, true },
+		namespace,
+		querier,
+	)
+	err = it.Each(func([]*iterator.TxBatch, uint64, uint64) error { return nil })
+	assert.ErrorContains(t, err, "version 3 of message")
 }
 
 func TestIteratorStartRange(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	connectivity "google.golang.org/grpc/connectivity"
 	iterator "pkg.world.dev/world-engine/cardinal/router/iterator"
 	txpool "pkg.world.dev/world-engine/cardinal/txpool"
 )
@@ -36,6 +37,20 @@ func (m *MockRouter) EXPECT() *MockRouterMockRecorder {
 	return m.recorder
 }
 
+// ConnectionState mocks base method.
+func (m *MockRouter) ConnectionState() connectivity.State {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectionState")
+	ret0, _ := ret[0].(connectivity.State)
+	return ret0
+}
+
+// ConnectionState indicates an expected call of ConnectionState.
+func (mr *MockRouterMockRecorder) ConnectionState() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectionState", reflect.TypeOf((*MockRouter)(nil).ConnectionState))
+}
+
 // RegisterGameShard mocks base method.
 func (m *MockRouter) RegisterGameShard(arg0 context.Context) error {
 	m.ctrl.T.Helper()
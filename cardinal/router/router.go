@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	ddotel "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/opentelemetry"
 	ddtracer "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
@@ -51,6 +52,9 @@ type Router interface {
 
 	TransactionIterator() iterator.Iterator
 
+	// ConnectionState returns the state of the connection to the base shard's game sequencer.
+	ConnectionState() connectivity.State
+
 	// Shutdown gracefully stops the EVM gRPC handler.
 	Shutdown()
 	// Start serves the EVM gRPC server.
@@ -60,6 +64,7 @@ type Router interface {
 type router struct {
 	provider          Provider
 	ShardSequencer    shard.TransactionHandlerClient
+	sequencerConn     *grpc.ClientConn
 	namespace         string
 	server            *evmServer
 	sequencerJobQueue *jobqueue.JobQueue[*shard.SubmitTransactionsRequest]
@@ -94,6 +99,7 @@ func New(namespace, sequencerAddr, routerKey string, world Provider, opts ...Opt
 		return nil, eris.Wrapf(err, "error dialing shard seqeuncer address at %q", sequencerAddr)
 	}
 	rtr.ShardSequencer = shard.NewTransactionHandlerClient(conn)
+	rtr.sequencerConn = conn
 
 	// The job queue will have been initialized if the router option for in-memory job queues is used.
 	// If it's not, we need to initialize it here.
@@ -144,12 +150,19 @@ func (r *router) SubmitTxBlob(
 		protoTxs := make([]*shard.Transaction, 0, len(txs))
 		for _, txData := range txs {
 			tx := txData.Tx
+			// TargetTick and ExpiryTick are part of the hash of the transaction, so they are submitted along with the
+			// rest of the transaction for recovery to reproduce the same hash.
 			protoTxs = append(protoTxs, &shard.Transaction{
 				PersonaTag: tx.PersonaTag,
 				Namespace:  tx.Namespace,
 				Nonce:      tx.Nonce,
 				Signature:  tx.Signature,
 				Body:       tx.Body,
+				TargetTick: tx.TargetTick,
+				ExpiryTick: tx.ExpiryTick,
+				// The body is encoded with the version of the message the transaction was sent as, which recovery
+				// needs to decode it.
+				MessageVersion: uint32(txData.MsgVersion),
 			})
 		}
 		messageIDtoTxs[uint64(msgID)] = &shard.Transactions{Txs: protoTxs}
@@ -176,6 +189,10 @@ func (r *router) TransactionIterator() iterator.Iterator {
 	return iterator.New(r.provider.GetMessageByID, r.namespace, r.ShardSequencer)
 }
 
+func (r *router) ConnectionState() connectivity.State {
+	return r.sequencerConn.GetState()
+}
+
 func (r *router) Shutdown() {
 	if r.server != nil {
 		r.server.grpcServer.GracefulStop()
@@ -69,6 +69,18 @@ func (f *mockMsg) IsEVMCompatible() bool {
 	return f.evmCompat
 }
 
+func (f *mockMsg) Version() int {
+	return 1
+}
+
+func (f *mockMsg) PreviousVersions() []types.Message {
+	return nil
+}
+
+func (f *mockMsg) IsSystemOnly() bool {
+	return false
+}
+
 func (f *mockMsg) GetInFieldInformation() map[string]any {
 	return map[string]any{"foo": "bar"}
 }
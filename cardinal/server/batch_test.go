@@ -0,0 +1,116 @@
+package server_test
+
+import (
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/sign"
+)
+
+func (s *ServerTestSuite) newBatchTransaction(personaTag string, nonce uint64, payload any) handler.BatchTransaction {
+	tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), nonce, payload)
+	s.Require().NoError(err)
+	return handler.BatchTransaction{
+		Group: "game",
+		Name:  moveMsgName,
+		Tx:    *tx,
+	}
+}
+
+func (s *ServerTestSuite) TestCanSubmitBatchTransaction() {
+	s.setupWorld()
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+
+	req := handler.PostBatchTransactionRequest{
+		Transactions: []handler.BatchTransaction{
+			s.newBatchTransaction(personaTag, s.nonce, MoveMsgInput{Direction: "up"}),
+			s.newBatchTransaction(personaTag, s.nonce+1, MoveMsgInput{Direction: "right"}),
+		},
+	}
+	s.nonce += 2
+	res := s.fixture.Post("tx/batch", req)
+	body := s.readBody(res.Body)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode, body)
+	var reply handler.PostBatchTransactionResponse
+	s.Require().NoError(json.Unmarshal([]byte(body), &reply))
+	s.Require().Len(reply.TxHashes, 2)
+	s.Require().Equal(s.world.CurrentTick(), reply.Tick)
+	s.fixture.DoTick()
+
+	// Both messages were executed in the same tick.
+	receipts, err := s.world.GetTransactionReceiptsForTick(reply.Tick)
+	s.Require().NoError(err)
+	s.Require().Len(receipts, 2)
+	for _, r := range receipts {
+		s.Require().Contains(reply.TxHashes, string(r.TxHash))
+		s.Require().Empty(r.Errs)
+	}
+
+	res = s.fixture.Post("query/game/location", QueryLocationRequest{Persona: personaTag})
+	var loc LocationComponent
+	s.Require().NoError(json.Unmarshal([]byte(s.readBody(res.Body)), &loc))
+	s.Require().Equal(LocationComponent{1, 1}, loc)
+}
+
+func (s *ServerTestSuite) TestBatchTransactionIsAllOrNothing() {
+	s.setupWorld()
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	otherPersonaTag := s.CreateRandomPersona()
+
+	firstTx := s.newBatchTransaction(personaTag, s.nonce, MoveMsgInput{Direction: "up"})
+	badSignatureTx := s.newBatchTransaction(personaTag, s.nonce+1, MoveMsgInput{Direction: "up"})
+	badSignatureTx.Tx.Signature = firstTx.Tx.Signature
+	targetedTx := s.newBatchTransaction(personaTag, s.nonce+1, MoveMsgInput{Direction: "up"})
+	targetedTx.Tx.TargetTick = 100
+	expiringTx := s.newBatchTransaction(personaTag, s.nonce+1, MoveMsgInput{Direction: "up"})
+	expiringTx.Tx.ExpiryTick = 100
+	testCases := []struct {
+		name string
+		txs  []handler.BatchTransaction
+	}{
+		{
+			name: "empty batch",
+			txs:  nil,
+		},
+		{
+			name: "different persona tags",
+			txs: []handler.BatchTransaction{
+				firstTx,
+				s.newBatchTransaction(otherPersonaTag, s.nonce+1, MoveMsgInput{Direction: "up"}),
+			},
+		},
+		{
+			name: "different target ticks",
+			txs:  []handler.BatchTransaction{firstTx, targetedTx},
+		},
+		{
+			name: "different expiry ticks",
+			txs:  []handler.BatchTransaction{firstTx, expiringTx},
+		},
+		{
+			name: "invalid signature",
+			txs:  []handler.BatchTransaction{firstTx, badSignatureTx},
+		},
+		{
+			name: "duplicate nonce",
+			txs:  []handler.BatchTransaction{firstTx, firstTx},
+		},
+	}
+	for _, tc := range testCases {
+		res := s.fixture.Post("tx/batch", handler.PostBatchTransactionRequest{Transactions: tc.txs})
+		s.Require().NotEqual(fiber.StatusOK, res.StatusCode, tc.name)
+	}
+	s.fixture.DoTick()
+
+	// None of the failed batches should have been added to the pool or used any nonces.
+	receipts, err := s.world.GetTransactionReceiptsForTick(s.world.CurrentTick() - 1)
+	s.Require().NoError(err)
+	s.Require().Empty(receipts)
+	res := s.fixture.Post(utils.GetTxURL("game", moveMsgName), firstTx.Tx)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode, s.readBody(res.Body))
+}
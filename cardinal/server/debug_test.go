@@ -1,11 +1,18 @@
 package server_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gofiber/fiber/v2"
 
 	"pkg.world.dev/world-engine/cardinal"
 	"pkg.world.dev/world-engine/cardinal/server/handler"
 	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
 )
 
 func (s *ServerTestSuite) TestDebugStateQuery() {
@@ -60,3 +67,83 @@ func (s *ServerTestSuite) TestDebugStateQuery_NoState() {
 
 	s.Require().Equal(len(results), 0)
 }
+
+func (s *ServerTestSuite) TestDebugRoutesRequireAPIKey() {
+	s.setupWorld(cardinal.WithDebugAPIKey("secret"))
+	s.fixture.DoTick()
+
+	for _, route := range []string{"/cql", "/debug/state"} {
+		res := s.fixture.Post(route, handler.CQLQueryRequest{CQL: "CONTAINS(location)"})
+		s.Require().Equal(fiber.StatusUnauthorized, res.StatusCode, route)
+		res = s.postWithAuthorization(route, "Bearer wrong", handler.CQLQueryRequest{CQL: "CONTAINS(location)"})
+		s.Require().Equal(fiber.StatusUnauthorized, res.StatusCode, route)
+		res = s.postWithAuthorization(route, "Bearer secret", handler.CQLQueryRequest{CQL: "CONTAINS(location)"})
+		s.Require().Equal(fiber.StatusOK, res.StatusCode, route)
+	}
+}
+
+func (s *ServerTestSuite) TestDebugRoutesAcceptSignedRequests() {
+	s.setupWorld(cardinal.WithDebugSignerAddress(s.signerAddr))
+	s.fixture.DoTick()
+	wCtx := cardinal.NewWorldContext(s.world)
+	_, err := cardinal.Create(wCtx, LocationComponent{})
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+
+	cql, err := json.Marshal(handler.CQLQueryRequest{CQL: "CONTAINS(location)"})
+	s.Require().NoError(err)
+	tx, err := sign.NewSystemTransaction(s.privateKey, s.world.Namespace(), s.nonce,
+		handler.DebugRequest{Path: "/cql", Request: cql})
+	s.Require().NoError(err)
+	res := s.fixture.Post("/cql", tx)
+	defer res.Body.Close()
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+	var results handler.CQLQueryResponse
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&results))
+	s.Require().Len(results.Results, 1)
+
+	// The same request cannot be replayed.
+	res = s.fixture.Post("/cql", tx)
+	s.Require().Equal(fiber.StatusUnauthorized, res.StatusCode)
+
+	// A request signed for one route cannot be sent to another.
+	s.nonce++
+	tx, err = sign.NewSystemTransaction(s.privateKey, s.world.Namespace(), s.nonce,
+		handler.DebugRequest{Path: "/cql", Request: cql})
+	s.Require().NoError(err)
+	res = s.fixture.Post("/debug/state", tx)
+	s.Require().Equal(fiber.StatusUnauthorized, res.StatusCode)
+
+	// Requests signed by anyone else are rejected.
+	otherKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	tx, err = sign.NewSystemTransaction(otherKey, s.world.Namespace(), 1,
+		handler.DebugRequest{Path: "/debug/state"})
+	s.Require().NoError(err)
+	res = s.fixture.Post("/debug/state", tx)
+	s.Require().Equal(fiber.StatusUnauthorized, res.StatusCode)
+}
+
+func (s *ServerTestSuite) TestDebugRoutesCanBeDisabled() {
+	s.setupWorld(cardinal.WithDisableDebugRoutes())
+	s.fixture.DoTick()
+
+	for _, route := range []string{"/cql", "/debug/state"} {
+		res := s.fixture.Post(route, handler.CQLQueryRequest{CQL: "CONTAINS(location)"})
+		s.Require().Equal(fiber.StatusNotFound, res.StatusCode, route)
+	}
+}
+
+// postWithAuthorization sends a POST request with the given Authorization header to the cardinal server.
+func (s *ServerTestSuite) postWithAuthorization(path, authorization string, payload any) *http.Response {
+	bz, err := json.Marshal(payload)
+	s.Require().NoError(err)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost,
+		"http://"+s.fixture.BaseURL+path, bytes.NewReader(bz))
+	s.Require().NoError(err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", authorization)
+	res, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	return res
+}
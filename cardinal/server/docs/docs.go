@@ -48,6 +48,12 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
@@ -68,17 +74,79 @@ const docTemplate = `{
                                 "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_types.DebugStateElement"
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Missing or invalid credentials",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
         },
         "/events": {
             "get": {
-                "description": "Establishes a new websocket connection to retrieve system events",
+                "description": "Establishes a new websocket connection to retrieve system events. The results of each tick are\nsent until the client sends a SubscriptionRequest, after which only the TopicEvents that match\nits topics are sent. Topics are event:\u003ctype\u003e, message:\u003cfull name\u003e, receipt:\u003ctx hash\u003e and\nentity:\u003cid\u003e. Each SubscriptionRequest is answered with a SubscriptionResponse. If fromTick is\nset, the HistoricalTickResults of the retained ticks since fromTick are sent first.",
                 "produces": [
                     "application/json"
                 ],
                 "summary": "Establishes a new websocket connection to retrieve system events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First tick to resume receiving the results of",
+                        "name": "fromTick",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switch protocol to ws",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/history": {
+            "get": {
+                "description": "Retrieves the events and receipts of the ticks since fromTick, so that clients can catch up on\nthe events they missed while disconnected",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retrieves the events of past ticks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "First tick to retrieve the events of",
+                        "name": "fromTick",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Results of the past ticks",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.EventHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid fromTick",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/private": {
+            "get": {
+                "description": "Establishes a new websocket connection to retrieve the events emitted to a single persona. The\nserver first sends a PrivateEventsChallenge. The client must reply with a transaction signed by the\npersona's signer whose body is the challenge, after which a PrivateEventsSubscription is sent and\nthe PrivateEvents of each tick are delivered.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Establishes a new websocket connection to retrieve the private events of a persona",
                 "responses": {
                     "101": {
                         "description": "Switch protocol to ws",
@@ -91,7 +159,7 @@ const docTemplate = `{
         },
         "/health": {
             "get": {
-                "description": "Retrieves the status of the server and game loop",
+                "description": "Retrieves the stage of the world, its last tick, the connectivity of Redis and the base shard, and\nthe progress of its recovery. Returns 503 once the world is shutting down.",
                 "produces": [
                     "application/json"
                 ],
@@ -102,6 +170,29 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/cardinal_server_handler.GetHealthResponse"
                         }
+                    },
+                    "503": {
+                        "description": "World is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.GetHealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/openapi.json": {
+            "get": {
+                "description": "Retrieves an OpenAPI 3.1 document generated from the messages, queries and components registered in\nthe world. Unlike the static swagger document, it describes the request body of every message and\nquery, and the schema of every component.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retrieves the OpenAPI document of the registered messages and queries",
+                "responses": {
+                    "200": {
+                        "description": "OpenAPI document",
+                        "schema": {
+                            "type": "object"
+                        }
                     }
                 }
             }
@@ -194,6 +285,174 @@ const docTemplate = `{
                 }
             }
         },
+        "/query/{queryGroup}/{queryVersion}/{queryName}": {
+            "post": {
+                "description": "Executes the given version of a query. Older versions of a query are served as long as they remain\nregistered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Executes a version of a query",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Query group",
+                        "name": "queryGroup",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version of the query, e.g. v2",
+                        "name": "queryVersion",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of a registered query",
+                        "name": "queryName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Query to be executed",
+                        "name": "queryBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Results of the executed query",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Query or query version not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ready": {
+            "get": {
+                "description": "Returns 200 once the world has recovered from the base shard and is running, and can reach Redis.\nReturns 503 otherwise, e.g. while the world is still replaying the transactions of the base shard.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retrieves whether the world is ready to serve requests",
+                "responses": {
+                    "200": {
+                        "description": "World is ready",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.GetHealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "World is not ready",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.GetHealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/receipt/{txHash}": {
+            "get": {
+                "description": "Retrieves the receipt of a transaction by its hash, once the tick it was executed in has completed.\nTransactions that are waiting for their target tick have a 202 status with the target tick instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retrieves the receipt of a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Hash of the transaction",
+                        "name": "txHash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Receipt of the transaction",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.ReceiptEntry"
+                        }
+                    },
+                    "202": {
+                        "description": "Transaction is waiting for its target tick",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.PendingEntry"
+                        }
+                    },
+                    "404": {
+                        "description": "Receipt not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tx/batch": {
+            "post": {
+                "description": "Submits a batch of transactions from a single persona that are all executed in the same tick.\nThe batch is validated as a whole; if any transaction is invalid, none of them are submitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Submits a batch of transactions",
+                "parameters": [
+                    {
+                        "description": "Transactions to be submitted",
+                        "name": "batch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.PostBatchTransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction hashes and tick",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.PostBatchTransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Persona is not authorized to send a message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
         "/tx/game/{txName}": {
             "post": {
                 "description": "Submits a transaction",
@@ -234,6 +493,12 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "403": {
+                        "description": "Persona is not authorized to send the message",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
             }
@@ -275,16 +540,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/tx/{txGroup}/{txName}": {
+        "/tx/simulate/{txGroup}/{txName}": {
             "post": {
-                "description": "Submits a transaction",
+                "description": "Runs the systems of the next tick with the transaction as its only transaction, and returns the\nreceipt the transaction would get and the state changes the tick would make. Nothing is committed:\nthe game state is unchanged, the nonce of the transaction is not used, and no events are emitted.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Submits a transaction",
+                "summary": "Simulates a transaction",
                 "parameters": [
                     {
                         "type": "string",
@@ -301,7 +566,7 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Transaction details \u0026 message to be submitted",
+                        "description": "Transaction details \u0026 message to be simulated",
                         "name": "txBody",
                         "in": "body",
                         "required": true,
@@ -312,13 +577,37 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Transaction hash and tick",
+                        "description": "Simulated tick, receipt, and state changes",
                         "schema": {
-                            "$ref": "#/definitions/cardinal_server_handler.PostTransactionResponse"
+                            "$ref": "#/definitions/cardinal_server_handler.SimulateTransactionResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameter",
+                        "description": "Invalid request or message failed validation",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Persona is not authorized to send the message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Another transaction is being simulated",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Simulation failed, e.g. because a system panicked",
                         "schema": {
                             "type": "string"
                         }
@@ -326,24 +615,169 @@ const docTemplate = `{
                 }
             }
         },
-        "/world": {
-            "get": {
-                "description": "Contains the registered components, messages, queries, and namespace",
+        "/tx/{txGroup}/{txName}": {
+            "post": {
+                "description": "Submits a transaction. If wait is true, the request blocks until the transaction has been executed\nand its receipt is returned inline. If the tick of the transaction completes without a receipt for\nit, or the timeout expires first, the transaction hash and tick are returned with a 202 status.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Retrieves details of the game world",
-                "responses": {
-                    "200": {
-                        "description": "Details of the game world",
+                "summary": "Submits a transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message group",
+                        "name": "txGroup",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of a registered message",
+                        "name": "txName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction details \u0026 message to be submitted",
+                        "name": "txBody",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/cardinal_server_handler.GetWorldResponse"
+                            "$ref": "#/definitions/cardinal_server_handler.Transaction"
                         }
                     },
-                    "400": {
+                    {
+                        "type": "boolean",
+                        "description": "Wait for the transaction to be executed",
+                        "name": "wait",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Milliseconds to wait (default 10000)",
+                        "name": "timeout",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction hash and tick, and receipt if waited for",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.PostTransactionResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Transaction hash and tick, receipt not yet available",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.PostTransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameter or message failed validation",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Persona is not authorized to send the message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tx/{txGroup}/{txVersion}/{txName}": {
+            "post": {
+                "description": "Submits a transaction for the given version of a message. Messages of older versions are converted\nto the latest version before they are executed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Submits a transaction for a version of a message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message group",
+                        "name": "txGroup",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Version of the message, e.g. v2",
+                        "name": "txVersion",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of a registered message",
+                        "name": "txName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transaction details \u0026 message to be submitted",
+                        "name": "txBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.Transaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction hash and tick",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.PostTransactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameter or message failed validation",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Persona is not authorized to send the message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Message or message version not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/world": {
+            "get": {
+                "description": "Contains the registered components, messages, queries, events, and namespace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Retrieves details of the game world",
+                "responses": {
+                    "200": {
+                        "description": "Details of the game world",
+                        "schema": {
+                            "$ref": "#/definitions/cardinal_server_handler.GetWorldResponse"
+                        }
+                    },
+                    "400": {
                         "description": "Invalid request parameters",
                         "schema": {
                             "type": "string"
@@ -354,6 +788,24 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "cardinal_server_handler.BatchTransaction": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tx": {
+                    "$ref": "#/definitions/cardinal_server_handler.Transaction"
+                },
+                "version": {
+                    "description": "Version is the version of the message. The latest version is used if it is not set.",
+                    "type": "integer"
+                }
+            }
+        },
         "cardinal_server_handler.CQLQueryRequest": {
             "type": "object",
             "properties": {
@@ -373,14 +825,71 @@ const docTemplate = `{
                 }
             }
         },
+        "cardinal_server_handler.EventHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "endTick": {
+                    "type": "integer"
+                },
+                "startTick": {
+                    "type": "integer"
+                },
+                "ticks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cardinal_server_handler.HistoricalTickResults"
+                    }
+                }
+            }
+        },
         "cardinal_server_handler.GetHealthResponse": {
             "type": "object",
             "properties": {
                 "isGameLoopRunning": {
                     "type": "boolean"
                 },
+                "isReady": {
+                    "description": "IsReady is true if the world is running and can reach Redis, which is when /ready returns 200.",
+                    "type": "boolean"
+                },
                 "isServerRunning": {
                     "type": "boolean"
+                },
+                "lastTick": {
+                    "description": "LastTick is the last tick that was completed. It is omitted until the world completes a tick.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_types.TickStatus"
+                        }
+                    ]
+                },
+                "recovery": {
+                    "description": "Recovery is the progress of the replay of the transactions of the base shard. It is omitted if the world did not\nrecover from the base shard.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_types.RecoveryProgress"
+                        }
+                    ]
+                },
+                "redis": {
+                    "description": "Redis is the connectivity of the storage of the world.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_types.ServiceStatus"
+                        }
+                    ]
+                },
+                "router": {
+                    "description": "Router is the connectivity of the base shard. It is omitted if the world is not in rollup mode.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_types.ServiceStatus"
+                        }
+                    ]
+                },
+                "stage": {
+                    "description": "Stage is the worldstage of the world, e.g. Recovering, Running or ShuttingDown.",
+                    "type": "string"
                 }
             }
         },
@@ -394,6 +903,13 @@ const docTemplate = `{
                         "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_types.FieldDetail"
                     }
                 },
+                "events": {
+                    "description": "events that systems emit with EmitTypedEvent",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_types.EventDetail"
+                    }
+                },
                 "messages": {
                     "type": "array",
                     "items": {
@@ -411,9 +927,38 @@ const docTemplate = `{
                 }
             }
         },
+        "cardinal_server_handler.HistoricalTickResults": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_receipt.Receipt"
+                    }
+                },
+                "tick": {
+                    "type": "integer"
+                }
+            }
+        },
         "cardinal_server_handler.ListTxReceiptsRequest": {
             "type": "object",
             "properties": {
+                "messageName": {
+                    "type": "string"
+                },
+                "personaTag": {
+                    "type": "string"
+                },
                 "startTick": {
                     "type": "integer"
                 }
@@ -425,6 +970,12 @@ const docTemplate = `{
                 "endTick": {
                     "type": "integer"
                 },
+                "pending": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cardinal_server_handler.PendingEntry"
+                    }
+                },
                 "receipts": {
                     "type": "array",
                     "items": {
@@ -436,9 +987,48 @@ const docTemplate = `{
                 }
             }
         },
+        "cardinal_server_handler.PendingEntry": {
+            "type": "object",
+            "properties": {
+                "targetTick": {
+                    "type": "integer"
+                },
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "cardinal_server_handler.PostBatchTransactionRequest": {
+            "type": "object",
+            "properties": {
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/cardinal_server_handler.BatchTransaction"
+                    }
+                }
+            }
+        },
+        "cardinal_server_handler.PostBatchTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "tick": {
+                    "type": "integer"
+                },
+                "txHashes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "cardinal_server_handler.PostTransactionResponse": {
             "type": "object",
             "properties": {
+                "receipt": {
+                    "$ref": "#/definitions/cardinal_server_handler.ReceiptEntry"
+                },
                 "tick": {
                     "type": "integer"
                 },
@@ -456,6 +1046,12 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "messageName": {
+                    "type": "string"
+                },
+                "personaTag": {
+                    "type": "string"
+                },
                 "result": {},
                 "tick": {
                     "type": "integer"
@@ -465,6 +1061,23 @@ const docTemplate = `{
                 }
             }
         },
+        "cardinal_server_handler.SimulateTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "receipt": {
+                    "$ref": "#/definitions/cardinal_server_handler.ReceiptEntry"
+                },
+                "stateChanges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pkg_world_dev_world-engine_cardinal_types.EntityStateChange"
+                    }
+                },
+                "tick": {
+                    "type": "integer"
+                }
+            }
+        },
         "cardinal_server_handler.Transaction": {
             "type": "object",
             "properties": {
@@ -472,6 +1085,10 @@ const docTemplate = `{
                     "description": "json string",
                     "type": "object"
                 },
+                "expiryTick": {
+                    "description": "ExpiryTick is the last tick this transaction can be executed in. A zero value means the transaction never\nexpires.",
+                    "type": "integer"
+                },
                 "hash": {
                     "type": "string"
                 },
@@ -487,13 +1104,36 @@ const docTemplate = `{
                 "signature": {
                     "description": "hex encoded string",
                     "type": "string"
+                },
+                "targetTick": {
+                    "description": "TargetTick is the tick this transaction should be executed in. A zero value means the transaction will be\nexecuted in the next available tick.",
+                    "type": "integer"
                 }
             }
         },
-        "pkg_world_dev_world-engine_cardinal_types.EntityStateElement": {
+        "pkg_world_dev_world-engine_cardinal_receipt.Receipt": {
             "type": "object",
             "properties": {
-                "data": {
+                "errs": {
+                    "type": "array",
+                    "items": {}
+                },
+                "msgName": {
+                    "type": "string"
+                },
+                "personaTag": {
+                    "type": "string"
+                },
+                "result": {},
+                "txHash": {
+                    "type": "string"
+                }
+            }
+        },
+        "pkg_world_dev_world-engine_cardinal_types.DebugStateElement": {
+            "type": "object",
+            "properties": {
+                "components": {
                     "type": "object"
                 },
                 "id": {
@@ -501,14 +1141,52 @@ const docTemplate = `{
                 }
             }
         },
-        "pkg_world_dev_world-engine_cardinal_types.DebugStateElement": {
+        "pkg_world_dev_world-engine_cardinal_types.EntityStateChange": {
             "type": "object",
             "properties": {
                 "components": {
                     "type": "object"
                 },
+                "created": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
+                },
+                "removed": {
+                    "type": "boolean"
+                },
+                "removedComponents": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "pkg_world_dev_world-engine_cardinal_types.EntityStateElement": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pkg_world_dev_world-engine_cardinal_types.EventDetail": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
+                    "type": "string"
+                },
+                "schema": {
+                    "type": "object"
                 }
             }
         },
@@ -524,8 +1202,68 @@ const docTemplate = `{
                     "description": "name of the message or query",
                     "type": "string"
                 },
+                "systemOnly": {
+                    "description": "SystemOnly is set for messages that can only be sent with a system transaction.",
+                    "type": "boolean"
+                },
                 "url": {
                     "type": "string"
+                },
+                "version": {
+                    "description": "Version is the version of the message or query. Older versions are listed separately from the latest version.",
+                    "type": "integer"
+                }
+            }
+        },
+        "pkg_world_dev_world-engine_cardinal_types.RecoveryProgress": {
+            "type": "object",
+            "properties": {
+                "complete": {
+                    "description": "Complete is true once every transaction of the base shard has been replayed.",
+                    "type": "boolean"
+                },
+                "currentTick": {
+                    "description": "CurrentTick is the tick that is being replayed.",
+                    "type": "integer"
+                },
+                "replayedTicks": {
+                    "description": "ReplayedTicks is the number of ticks whose transactions have been replayed.",
+                    "type": "integer"
+                },
+                "startTick": {
+                    "description": "StartTick is the tick the recovery started from.",
+                    "type": "integer"
+                }
+            }
+        },
+        "pkg_world_dev_world-engine_cardinal_types.ServiceStatus": {
+            "type": "object",
+            "properties": {
+                "connected": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State is the state of the connection, e.g. the gRPC connectivity state of the router.",
+                    "type": "string"
+                }
+            }
+        },
+        "pkg_world_dev_world-engine_cardinal_types.TickStatus": {
+            "type": "object",
+            "properties": {
+                "ageMs": {
+                    "description": "AgeMs is the number of milliseconds since the timestamp of the tick.",
+                    "type": "integer"
+                },
+                "tick": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "Timestamp is the unix timestamp of the tick in milliseconds. The timestamps of recovered ticks are the ones they\noriginally ran at.",
+                    "type": "integer"
                 }
             }
         }
@@ -1,16 +1,24 @@
 package server_test
 
 import (
+	"crypto/ecdsa"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gorilla/websocket"
 
 	"pkg.world.dev/world-engine/assert"
 	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
 )
 
 type SendEnergyTx struct {
@@ -117,3 +125,272 @@ func TestEventsThroughSystems(t *testing.T) {
 func wsURL(addr, path string) string {
 	return fmt.Sprintf("ws://%s/%s", addr, path)
 }
+
+func TestPrivateEventsAreOnlySentToTheirPersona(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil)
+	world, addr := tf.World, tf.BaseURL
+	err := cardinal.RegisterSystems(world, func(wCtx cardinal.WorldContext) error {
+		if err := cardinal.EmitEventTo(wCtx, "alice", map[string]any{"hand": "alice-cards"}); err != nil {
+			return err
+		}
+		if err := cardinal.EmitEventTo(wCtx, "bob", map[string]any{"hand": "bob-cards"}); err != nil {
+			return err
+		}
+		return wCtx.EmitEvent(map[string]any{"table": "public"})
+	})
+	assert.NilError(t, err)
+	tf.StartWorld()
+
+	aliceKey, err := crypto.GenerateKey()
+	assert.NilError(t, err)
+	bobKey, err := crypto.GenerateKey()
+	assert.NilError(t, err)
+	tf.CreatePersona("alice", crypto.PubkeyToAddress(aliceKey.PublicKey).Hex())
+	tf.CreatePersona("bob", crypto.PubkeyToAddress(bobKey.PublicKey).Hex())
+
+	// subscribe answers the challenge of a private events connection by signing it as the given persona.
+	subscribe := func(personaTag string, key *ecdsa.PrivateKey) (*websocket.Conn, error) {
+		conn, _, err := websocket.DefaultDialer.Dial(wsURL(addr, "events/private"), nil)
+		assert.NilError(t, err)
+		var challenge handler.PrivateEventsChallenge
+		assert.NilError(t, conn.ReadJSON(&challenge))
+		tx, err := sign.NewTransaction(key, personaTag, world.Namespace(), 0, challenge)
+		assert.NilError(t, err)
+		assert.NilError(t, conn.WriteJSON(tx))
+		var subscription handler.PrivateEventsSubscription
+		if err = conn.ReadJSON(&subscription); err != nil {
+			return nil, err
+		}
+		assert.Equal(t, personaTag, subscription.PersonaTag)
+		return conn, nil
+	}
+
+	alice, err := subscribe("alice", aliceKey)
+	assert.NilError(t, err)
+	_, err = subscribe("bob", aliceKey)
+	assert.Check(t, websocket.IsCloseError(err, websocket.ClosePolicyViolation))
+	public, _, err := websocket.DefaultDialer.Dial(wsURL(addr, "events"), nil)
+	assert.NilError(t, err)
+
+	tf.DoTick()
+
+	var events handler.PrivateEvents
+	assert.NilError(t, alice.ReadJSON(&events))
+	assert.Equal(t, world.CurrentTick()-1, events.Tick)
+	assert.Equal(t, 1, len(events.Events))
+	assert.Equal(t, `{"hand":"alice-cards"}`, string(events.Events[0]))
+
+	// Private events are left out of the tick results that are broadcast to everyone.
+	var tickResults cardinal.TickResults
+	assert.NilError(t, public.ReadJSON(&tickResults))
+	assert.Equal(t, 1, len(tickResults.Events))
+	assert.Equal(t, `{"table":"public"}`, string(tickResults.Events[0]))
+}
+
+func TestEventsCanBeFilteredByTopic(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil)
+	world, addr := tf.World, tf.BaseURL
+	assert.NilError(t, cardinal.RegisterComponent[Alpha](world))
+	assert.NilError(t, cardinal.RegisterComponent[Beta](world))
+	var alphaID types.EntityID
+	err := cardinal.RegisterSystems(world, func(wCtx cardinal.WorldContext) error {
+		if wCtx.CurrentTick() == 0 {
+			id, err := cardinal.Create(wCtx, Alpha{Something: 1})
+			alphaID = id
+			return err
+		}
+		if err := cardinal.UpdateComponent[Alpha](wCtx, alphaID, func(a *Alpha) *Alpha {
+			a.Something++
+			return a
+		}); err != nil {
+			return err
+		}
+		if _, err := cardinal.Create(wCtx, Beta{Something: 1}); err != nil {
+			return err
+		}
+		if err := wCtx.EmitEvent(map[string]any{"type": "score", "points": 10}); err != nil {
+			return err
+		}
+		return wCtx.EmitEvent(map[string]any{"type": "chat", "text": "hello"})
+	})
+	assert.NilError(t, err)
+	tf.StartWorld()
+	tf.DoTick()
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(addr, "events"), nil)
+	assert.NilError(t, err)
+
+	var res handler.SubscriptionResponse
+	assert.NilError(t, conn.WriteJSON(handler.SubscriptionRequest{Subscribe: []string{"bogus"}}))
+	assert.NilError(t, conn.ReadJSON(&res))
+	assert.Check(t, res.Error != "")
+	assert.Equal(t, 0, len(res.Topics))
+
+	entityTopic := fmt.Sprintf("%s%d", handler.EntityTopicPrefix, alphaID)
+	assert.NilError(t, conn.WriteJSON(handler.SubscriptionRequest{
+		Subscribe: []string{handler.EventTopicPrefix + "score", entityTopic},
+	}))
+	res = handler.SubscriptionResponse{}
+	assert.NilError(t, conn.ReadJSON(&res))
+	assert.Equal(t, "", res.Error)
+	assert.Equal(t, 2, len(res.Topics))
+
+	tf.DoTick()
+
+	var topicEvents handler.TopicEvents
+	assert.NilError(t, conn.ReadJSON(&topicEvents))
+	assert.Equal(t, world.CurrentTick()-1, topicEvents.Tick)
+	assert.Equal(t, 1, len(topicEvents.Events))
+	assert.Equal(t, `{"points":10,"type":"score"}`, string(topicEvents.Events[0]))
+	assert.Equal(t, 0, len(topicEvents.Receipts))
+	assert.Equal(t, 1, len(topicEvents.StateChanges))
+	assert.Equal(t, alphaID, topicEvents.StateChanges[0].ID)
+	assert.Equal(t, `{"something":2}`, string(topicEvents.StateChanges[0].Components["alpha"]))
+
+	// Once the connection is no longer subscribed to the event, only the changes to the entity are sent.
+	assert.NilError(t, conn.WriteJSON(handler.SubscriptionRequest{
+		Unsubscribe: []string{handler.EventTopicPrefix + "score"},
+	}))
+	assert.NilError(t, conn.ReadJSON(&res))
+	assert.DeepEqual(t, []string{entityTopic}, res.Topics)
+
+	tf.DoTick()
+
+	topicEvents = handler.TopicEvents{}
+	assert.NilError(t, conn.ReadJSON(&topicEvents))
+	assert.Equal(t, 0, len(topicEvents.Events))
+	assert.Equal(t, 1, len(topicEvents.StateChanges))
+	assert.Equal(t, `{"something":3}`, string(topicEvents.StateChanges[0].Components["alpha"]))
+}
+
+type ScoreEvent struct {
+	Player string `json:"player"`
+	Points int    `json:"points"`
+}
+
+func TestTypedEventsAreListedAndEmitted(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil)
+	world, addr := tf.World, tf.BaseURL
+	assert.NilError(t, cardinal.RegisterEvent[ScoreEvent](world, "score"))
+	assert.ErrorContains(t, cardinal.RegisterEvent[ScoreEvent](world, "points"), "already registered")
+	assert.ErrorContains(t, cardinal.RegisterEvent[int](world, "count"), "must be a struct")
+	err := cardinal.RegisterSystems(world, func(wCtx cardinal.WorldContext) error {
+		if err := cardinal.EmitTypedEvent(wCtx, SendEnergyTx{}); err == nil {
+			return errors.New("expected unregistered event type to be rejected")
+		}
+		return cardinal.EmitTypedEvent(wCtx, ScoreEvent{Player: "alice", Points: 10})
+	})
+	assert.NilError(t, err)
+	tf.StartWorld()
+
+	res := tf.Get("world")
+	defer res.Body.Close()
+	var worldRes handler.GetWorldResponse
+	assert.NilError(t, json.NewDecoder(res.Body).Decode(&worldRes))
+	assert.Equal(t, 1, len(worldRes.Events))
+	assert.Equal(t, "score", worldRes.Events[0].Name)
+	assert.DeepEqual(t, map[string]any{"player": "string", "points": "int"}, worldRes.Events[0].Fields)
+	var schema map[string]any
+	assert.NilError(t, json.Unmarshal(worldRes.Events[0].Schema, &schema))
+	assert.Check(t, schema["$defs"] != nil)
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(addr, "events"), nil)
+	assert.NilError(t, err)
+	assert.NilError(t, conn.WriteJSON(handler.SubscriptionRequest{
+		Subscribe: []string{handler.EventTopicPrefix + "score"},
+	}))
+	var subRes handler.SubscriptionResponse
+	assert.NilError(t, conn.ReadJSON(&subRes))
+	assert.Equal(t, "", subRes.Error)
+
+	tf.DoTick()
+
+	var topicEvents handler.TopicEvents
+	assert.NilError(t, conn.ReadJSON(&topicEvents))
+	assert.Equal(t, 1, len(topicEvents.Events))
+	assert.Equal(t, `{"data":{"player":"alice","points":10},"type":"score"}`, string(topicEvents.Events[0]))
+}
+
+func TestMissedEventsCanBeCaughtUpOn(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil, cardinal.WithEventRetention(3))
+	world, addr := tf.World, tf.BaseURL
+	err := cardinal.RegisterSystems(world, func(wCtx cardinal.WorldContext) error {
+		return wCtx.EmitEvent(map[string]any{"tick": wCtx.CurrentTick()})
+	})
+	assert.NilError(t, err)
+	tf.StartWorld()
+	for i := 0; i < 5; i++ {
+		tf.DoTick()
+	}
+	endTick := world.CurrentTick()
+
+	getHistory := func(fromTick string) handler.EventHistoryResponse {
+		res := tf.Get("events/history?fromTick=" + fromTick)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		var history handler.EventHistoryResponse
+		assert.NilError(t, json.NewDecoder(res.Body).Decode(&history))
+		return history
+	}
+
+	// Only the events of the last 3 ticks are retained.
+	history := getHistory("0")
+	assert.Equal(t, endTick-3, history.StartTick)
+	assert.Equal(t, endTick, history.EndTick)
+	assert.Equal(t, 3, len(history.Ticks))
+
+	history = getHistory(strconv.FormatUint(endTick-1, 10))
+	assert.Equal(t, endTick-1, history.StartTick)
+	assert.Equal(t, 1, len(history.Ticks))
+	assert.Equal(t, endTick-1, history.Ticks[0].Tick)
+	assert.Equal(t, fmt.Sprintf(`{"tick":%d}`, endTick-1), string(history.Ticks[0].Events[0]))
+
+	res := tf.Get("events/history?fromTick=abc")
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	// A websocket connection that resumes from a tick is sent the results of the ticks it missed before the live ones.
+	url := wsURL(addr, "events?fromTick="+strconv.FormatUint(endTick-2, 10))
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.NilError(t, err)
+	tf.DoTick()
+	for tick := endTick - 2; tick <= endTick; tick++ {
+		var results cardinal.TickResults
+		assert.NilError(t, conn.ReadJSON(&results))
+		assert.Equal(t, tick, results.Tick)
+		assert.Equal(t, fmt.Sprintf(`{"tick":%d}`, tick), string(results.Events[0]))
+	}
+}
+
+func TestEventHistoryIsLimitedToMaxTicks(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil, cardinal.WithEventRetention(2*handler.MaxEventHistoryTicks))
+	world, addr := tf.World, tf.BaseURL
+	err := cardinal.RegisterSystems(world, func(wCtx cardinal.WorldContext) error {
+		return wCtx.EmitEvent(map[string]any{"tick": wCtx.CurrentTick()})
+	})
+	assert.NilError(t, err)
+	tf.StartWorld()
+	for world.CurrentTick() < handler.MaxEventHistoryTicks+5 {
+		tf.DoTick()
+	}
+	endTick := world.CurrentTick()
+
+	res := tf.Get("events/history?fromTick=0")
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	var history handler.EventHistoryResponse
+	assert.NilError(t, json.NewDecoder(res.Body).Decode(&history))
+	assert.Equal(t, uint64(0), history.StartTick)
+	assert.Equal(t, uint64(handler.MaxEventHistoryTicks), history.EndTick)
+	assert.Equal(t, handler.MaxEventHistoryTicks, len(history.Ticks))
+
+	// A websocket connection that resumes from further back is still sent the results of every tick it missed.
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(addr, "events?fromTick=0"), nil)
+	assert.NilError(t, err)
+	tf.DoTick()
+	for tick := uint64(0); tick <= endTick; tick++ {
+		var results cardinal.TickResults
+		assert.NilError(t, conn.ReadJSON(&results))
+		assert.Equal(t, tick, results.Tick)
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+
+	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+	"pkg.world.dev/world-engine/sign"
+)
+
+var (
+	ErrUnauthenticated = errors.New("a valid API key or signed request is required")
+	ErrWrongDebugRoute = errors.New("request was signed for a different route")
+)
+
+// DebugAuth is how requests to the debug routes, /debug/state and /cql, are authenticated. These routes can dump the
+// entire game state, so they are open only if neither an API key nor a signer address is set.
+type DebugAuth struct {
+	// Disabled removes the debug routes entirely.
+	Disabled bool
+	// APIKey is accepted as a bearer token in the Authorization header.
+	APIKey string
+	// SignerAddress is the address that signs requests. A signed request is a system transaction whose body is a
+	// DebugRequest.
+	SignerAddress string
+}
+
+// DebugRequest is the body of the system transaction of a signed request. Path binds the signature to a single route,
+// and Request is the body the route would get if it was unauthenticated.
+type DebugRequest struct {
+	Path    string          `json:"path"`
+	Request json.RawMessage `json:"request,omitempty"`
+}
+
+// IsOpen returns true if requests to the debug routes don't need to be authenticated.
+func (a DebugAuth) IsOpen() bool {
+	return a.APIKey == "" && a.SignerAddress == ""
+}
+
+// HasAPIKey returns true if the value of an Authorization header is a bearer token holding the API key.
+func (a DebugAuth) HasAPIKey(authorization string) bool {
+	key, ok := strings.CutPrefix(authorization, "Bearer ")
+	return ok && a.APIKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(a.APIKey)) == 1
+}
+
+// AuthenticateDebugRequest is the middleware of the debug routes. A signed request is replaced by the request it
+// contains before it is passed on to the route.
+func AuthenticateDebugRequest(world servertypes.ProviderWorld, auth DebugAuth) func(*fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		if auth.IsOpen() || auth.HasAPIKey(ctx.Get(fiber.HeaderAuthorization)) {
+			return ctx.Next()
+		}
+		if auth.SignerAddress == "" || ctx.Get(fiber.HeaderAuthorization) != "" {
+			return fiber.NewError(fiber.StatusUnauthorized, ErrUnauthenticated.Error())
+		}
+
+		tx, err := sign.UnmarshalTransaction(ctx.Body())
+		if err != nil {
+			return fiber.NewError(fiber.StatusUnauthorized, ErrUnauthenticated.Error())
+		}
+		req, err := verifyDebugRequest(world, auth.SignerAddress, tx, ctx.Path())
+		if err != nil {
+			return fiber.NewError(fiber.StatusUnauthorized, "failed to validate signed request: "+err.Error())
+		}
+		ctx.Request().SetBody(req.Request)
+		return ctx.Next()
+	}
+}
+
+// verifyDebugRequest verifies that the transaction was signed by the signer for the given route, and uses its nonce so
+// that it cannot be replayed.
+func verifyDebugRequest(
+	world servertypes.ProviderWorld, signerAddress string, tx *Transaction, path string,
+) (*DebugRequest, error) {
+	if err := validateSignature(tx, signerAddress, world.Namespace(), true); err != nil {
+		return nil, err
+	}
+	req := new(DebugRequest)
+	if err := json.Unmarshal(tx.Body, req); err != nil {
+		return nil, eris.Wrap(err, "failed to decode signed request")
+	}
+	if req.Path != path {
+		return nil, eris.Wrap(ErrWrongDebugRoute, "")
+	}
+	if err := world.UseNonce(signerAddress, tx.Nonce); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
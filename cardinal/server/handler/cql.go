@@ -24,6 +24,7 @@ type CQLQueryResponse struct {
 //	@Param        cql  body      CQLQueryRequest   true  "CQL query to be executed"
 //	@Success      200  {object}  CQLQueryResponse  "Results of the executed CQL query"
 //	@Failure      400  {string}  string            "Invalid request parameters"
+//	@Failure      401  {string}  string            "Missing or invalid credentials"
 //	@Router       /cql [post]
 func PostCQL(
 	world servertypes.ProviderWorld,
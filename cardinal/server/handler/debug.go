@@ -17,6 +17,7 @@ type DebugStateResponse = []types.DebugStateElement
 // @Description  Retrieves a list of all entities in the game state
 // @Produce      application/json
 // @Success      200  {object}  DebugStateResponse "List of all entities"
+// @Failure      401  {string}  string             "Missing or invalid credentials"
 // @Router       /debug/state [post]
 func GetState(world servertypes.ProviderWorld) func(*fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
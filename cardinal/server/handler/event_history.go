@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal/receipt"
+	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+)
+
+// MaxEventHistoryTicks is the largest number of ticks whose results are returned by a single request to
+// /events/history.
+const MaxEventHistoryTicks = 100
+
+// EventHistoryResponse returns the results of the ticks in the range [StartTick, EndTick) that had events or receipts.
+// StartTick is later than the requested tick if the events of the earlier ticks are no longer retained. At most
+// MaxEventHistoryTicks ticks are returned, so EndTick can be before the current tick. To keep up with future ticks, use
+// the returned EndTick as the fromTick of the next request.
+type EventHistoryResponse struct {
+	StartTick uint64                  `json:"startTick"`
+	EndTick   uint64                  `json:"endTick"`
+	Ticks     []HistoricalTickResults `json:"ticks"`
+}
+
+// HistoricalTickResults are the results of a past tick. They are encoded the same way as the tick results that are
+// broadcast on /events.
+type HistoricalTickResults struct {
+	Tick     uint64
+	Receipts []receipt.Receipt
+	Events   [][]byte
+}
+
+// GetEventHistory godoc
+//
+//	@Summary      Retrieves the events of past ticks
+//	@Description  Retrieves the events and receipts of the ticks since fromTick, so that clients can catch up on
+//	@Description  the events they missed while disconnected
+//	@Produce      application/json
+//	@Param        fromTick  query     int                   false  "First tick to retrieve the events of"
+//	@Success      200       {object}  EventHistoryResponse  "Results of the past ticks"
+//	@Failure      400       {string}  string                "Invalid fromTick"
+//	@Router       /events/history [get]
+func GetEventHistory(world servertypes.ProviderWorld) func(*fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		fromTick, err := fromTickQuery(ctx.Query)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "invalid fromTick: "+err.Error())
+		}
+		history, err := loadEventHistory(world, fromTick)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to get event history: "+err.Error())
+		}
+		return ctx.JSON(history)
+	}
+}
+
+// fromTickQuery returns the tick in the fromTick query parameter, or 0 if it is not set.
+func fromTickQuery(query func(key string, defaultValue ...string) string) (uint64, error) {
+	param := query("fromTick")
+	if param == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(param, 10, 64)
+}
+
+// loadEventHistory returns the results of the ticks since fromTick whose events are still retained, up to
+// MaxEventHistoryTicks ticks.
+func loadEventHistory(world servertypes.ProviderWorld, fromTick uint64) (EventHistoryResponse, error) {
+	history := EventHistoryResponse{}
+	history.EndTick = world.CurrentTick()
+	if size := world.EventHistorySize(); size < history.EndTick {
+		history.StartTick = history.EndTick - size
+	}
+	if fromTick > history.EndTick {
+		history.StartTick = history.EndTick
+	} else if fromTick > history.StartTick {
+		history.StartTick = fromTick
+	}
+	history.EndTick = min(history.EndTick, history.StartTick+MaxEventHistoryTicks)
+
+	events, err := world.GetEventsForTicks(history.StartTick, history.EndTick)
+	if err != nil {
+		return history, err
+	}
+	receipts, err := world.GetTransactionReceiptsForTicks(history.StartTick, history.EndTick)
+	if err != nil {
+		return history, err
+	}
+	for tick := history.StartTick; tick < history.EndTick; tick++ {
+		if len(events[tick]) == 0 && len(receipts[tick]) == 0 {
+			continue
+		}
+		history.Ticks = append(history.Ticks, HistoricalTickResults{
+			Tick:     tick,
+			Receipts: receipts[tick],
+			Events:   events[tick],
+		})
+	}
+	return history, nil
+}
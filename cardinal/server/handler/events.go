@@ -1,25 +1,303 @@
 package handler
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/gofiber/contrib/socketio"
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
 	"github.com/rs/zerolog/log"
+
+	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+)
+
+const (
+	// PrivateEventsAuthTimeout is how long a private events subscriber has to answer the challenge.
+	PrivateEventsAuthTimeout = 30 * time.Second
+	// privateEventsQueueSize is the number of messages that can be waiting to be sent to a private events subscriber.
+	// Messages are dropped if the subscriber falls further behind.
+	privateEventsQueueSize = 100
+	challengeSize          = 32
+	// eventSubscribersAttribute is the socketio attribute that holds the EventSubscribers of a connection.
+	eventSubscribersAttribute = "eventSubscribers"
 )
 
+var ErrChallengeMismatch = errors.New("signed challenge does not match")
+
+// registerEventListeners registers the socketio listeners, which are shared by every socketio handler, only once. The
+// listeners pass the events of a connection to the EventSubscribers stored on the connection.
+var registerEventListeners sync.Once
+
+// PrivateEventsChallenge is the challenge sent to a private events subscriber when it connects. The subscriber
+// authenticates by replying with a transaction signed by its persona's signer, whose body is the challenge.
+type PrivateEventsChallenge struct {
+	Challenge string `json:"challenge"`
+}
+
+// PrivateEventsSubscription is sent to a private events subscriber once it has authenticated as a persona.
+type PrivateEventsSubscription struct {
+	PersonaTag string `json:"personaTag"`
+}
+
+// PrivateEvents are the events of a tick that were emitted to a single persona.
+type PrivateEvents struct {
+	Tick   uint64            `json:"tick"`
+	Events []json.RawMessage `json:"events"`
+}
+
 // WebSocketEvents godoc
 //
 //	@Summary      Establishes a new websocket connection to retrieve system events
-//	@Description  Establishes a new websocket connection to retrieve system events
+//	@Description  Establishes a new websocket connection to retrieve system events. The results of each tick are
+//	@Description  sent until the client sends a SubscriptionRequest, after which only the TopicEvents that match
+//	@Description  its topics are sent. Topics are event:<type>, message:<full name>, receipt:<tx hash> and
+//	@Description  entity:<id>. Each SubscriptionRequest is answered with a SubscriptionResponse. If fromTick is
+//	@Description  set, the HistoricalTickResults of the retained ticks since fromTick are sent first.
 //	@Produce      application/json
-//	@Success      101  {string}  string  "Switch protocol to ws"
+//	@Param        fromTick  query     int     false  "First tick to resume receiving the results of"
+//	@Success      101       {string}  string  "Switch protocol to ws"
 //	@Router       /events [get]
-func WebSocketEvents() func(c *fiber.Ctx) error {
-	return socketio.New(func(_ *socketio.Websocket) {
-		log.Debug().Msg("new websocket connection established")
+func WebSocketEvents(world servertypes.ProviderWorld, subscribers *EventSubscribers) func(c *fiber.Ctx) error {
+	registerEventListeners.Do(func() {
+		socketio.On(socketio.EventMessage, func(ep *socketio.EventPayload) {
+			if subs, ok := ep.Kws.GetAttribute(eventSubscribersAttribute).(*EventSubscribers); ok {
+				subs.handleMessage(ep.SocketUUID, ep.Data)
+			}
+		})
+		removeSubscriber := func(ep *socketio.EventPayload) {
+			if subs, ok := ep.Kws.GetAttribute(eventSubscribersAttribute).(*EventSubscribers); ok {
+				subs.remove(ep.SocketUUID)
+			}
+		}
+		socketio.On(socketio.EventDisconnect, removeSubscriber)
+		socketio.On(socketio.EventClose, removeSubscriber)
+	})
+	return socketio.New(func(kws *socketio.Websocket) {
+		kws.SetAttribute(eventSubscribersAttribute, subscribers)
+		var fromTick uint64
+		var history func(uint64) (EventHistoryResponse, error)
+		if kws.Query("fromTick") != "" {
+			var err error
+			fromTick, err = fromTickQuery(kws.Query)
+			if err != nil {
+				log.Debug().Err(err).Msg("websocket connection has an invalid fromTick")
+				kws.Close()
+				return
+			}
+			history = func(from uint64) (EventHistoryResponse, error) {
+				return loadEventHistory(world, from)
+			}
+		}
+		if history == nil {
+			_ = subscribers.add(kws, 0, nil)
+			log.Debug().Msg("new websocket connection established")
+			return
+		}
+		// Messages are only written to the connection once this function returns, so the history, which can be larger
+		// than the send queue of the connection, is sent from another goroutine.
+		go func() {
+			if err := subscribers.add(kws, fromTick, history); err != nil {
+				log.Err(err).Msg("failed to send event history to websocket connection")
+				kws.Close()
+				return
+			}
+			log.Debug().Msg("new websocket connection established")
+		}()
+	})
+}
+
+// WebSocketPrivateEvents godoc
+//
+//	@Summary      Establishes a new websocket connection to retrieve the private events of a persona
+//	@Description  Establishes a new websocket connection to retrieve the events emitted to a single persona. The
+//	@Description  server first sends a PrivateEventsChallenge. The client must reply with a transaction signed by the
+//	@Description  persona's signer whose body is the challenge, after which a PrivateEventsSubscription is sent and
+//	@Description  the PrivateEvents of each tick are delivered.
+//	@Produce      application/json
+//	@Success      101  {string}  string  "Switch protocol to ws"
+//	@Router       /events/private [get]
+func WebSocketPrivateEvents(
+	world servertypes.ProviderWorld, subscribers *PrivateEventSubscribers,
+) func(*fiber.Ctx) error {
+	return websocket.New(func(c *websocket.Conn) {
+		personaTag, err := authenticatePrivateEventsSubscriber(world, c)
+		if err != nil {
+			log.Debug().Err(err).Msg("private events subscriber failed to authenticate")
+			_ = c.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()))
+			return
+		}
+
+		sub := &privateEventSubscriber{
+			conn:     c,
+			messages: make(chan []byte, privateEventsQueueSize),
+		}
+		subscribers.add(personaTag, sub)
+		defer subscribers.remove(personaTag, sub)
+
+		// The connection is closed once the subscriber stops reading, as nothing else is expected from it.
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := c.ReadMessage(); err != nil {
+					return
+				}
+			}
+		}()
+
+		for {
+			select {
+			case msg := <-sub.messages:
+				if err := c.WriteMessage(websocket.TextMessage, msg); err != nil {
+					return
+				}
+			case <-closed:
+				return
+			}
+		}
 	})
 }
 
+// authenticatePrivateEventsSubscriber challenges the subscriber to prove that it holds the signer key of a persona,
+// and returns the tag of that persona.
+func authenticatePrivateEventsSubscriber(world servertypes.ProviderWorld, c *websocket.Conn) (string, error) {
+	bz := make([]byte, challengeSize)
+	if _, err := rand.Read(bz); err != nil {
+		return "", eris.Wrap(err, "failed to generate challenge")
+	}
+	challenge := PrivateEventsChallenge{Challenge: hex.EncodeToString(bz)}
+	if err := c.WriteJSON(challenge); err != nil {
+		return "", eris.Wrap(err, "failed to send challenge")
+	}
+
+	if err := c.SetReadDeadline(time.Now().Add(PrivateEventsAuthTimeout)); err != nil {
+		return "", eris.Wrap(err, "")
+	}
+	tx := new(Transaction)
+	if err := c.ReadJSON(tx); err != nil {
+		return "", eris.Wrap(err, "failed to read signed challenge")
+	}
+	if err := c.SetReadDeadline(time.Time{}); err != nil {
+		return "", eris.Wrap(err, "")
+	}
+
+	var signed PrivateEventsChallenge
+	if err := json.Unmarshal(tx.Body, &signed); err != nil || signed != challenge {
+		return "", eris.Wrap(ErrChallengeMismatch, "")
+	}
+	if tx.PersonaTag == "" {
+		return "", eris.Wrap(ErrNoPersonaTag, "")
+	}
+	// The hash is recomputed from the transaction so the signature is checked against the signed challenge, rather
+	// than against whatever hash the subscriber sent.
+	tx.Hash = common.Hash{}
+	signerAddress, err := world.GetSignerForPersonaTag(tx.PersonaTag, 0)
+	if err != nil {
+		return "", eris.Wrap(err, "could not get signer for persona")
+	}
+	if err = validateSignature(tx, signerAddress, world.Namespace(), false); err != nil {
+		return "", err
+	}
+
+	if err = c.WriteJSON(PrivateEventsSubscription{PersonaTag: tx.PersonaTag}); err != nil {
+		return "", eris.Wrap(err, "failed to send subscription")
+	}
+	return tx.PersonaTag, nil
+}
+
+// PrivateEventSubscribers keeps track of the websocket connections that subscribed to the private events of each
+// persona.
+type PrivateEventSubscribers struct {
+	mux         sync.RWMutex
+	subscribers map[string]map[*privateEventSubscriber]struct{}
+}
+
+type privateEventSubscriber struct {
+	conn     *websocket.Conn
+	messages chan []byte
+}
+
+func NewPrivateEventSubscribers() *PrivateEventSubscribers {
+	return &PrivateEventSubscribers{
+		subscribers: map[string]map[*privateEventSubscriber]struct{}{},
+	}
+}
+
+// Send sends the private events of a tick to every subscriber of the given persona.
+func (p *PrivateEventSubscribers) Send(personaTag string, tick uint64, events [][]byte) {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	if len(p.subscribers[personaTag]) == 0 {
+		return
+	}
+
+	msg := PrivateEvents{Tick: tick, Events: make([]json.RawMessage, 0, len(events))}
+	for _, event := range events {
+		msg.Events = append(msg.Events, event)
+	}
+	bz, err := json.Marshal(msg)
+	if err != nil {
+		log.Err(err).Msg("failed to marshal private events")
+		return
+	}
+	for sub := range p.subscribers[personaTag] {
+		select {
+		case sub.messages <- bz:
+		default:
+			log.Warn().Str("persona_tag", personaTag).Uint64("tick", tick).
+				Msg("private events subscriber is too slow, dropping events")
+		}
+	}
+}
+
+// Close closes the connections of all subscribers.
+func (p *PrivateEventSubscribers) Close() {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	for _, subs := range p.subscribers {
+		for sub := range subs {
+			_ = sub.conn.Close()
+		}
+	}
+}
+
+// Count returns the number of open connections.
+func (p *PrivateEventSubscribers) Count() int {
+	p.mux.RLock()
+	defer p.mux.RUnlock()
+	count := 0
+	for _, subs := range p.subscribers {
+		count += len(subs)
+	}
+	return count
+}
+
+func (p *PrivateEventSubscribers) add(personaTag string, sub *privateEventSubscriber) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	if p.subscribers[personaTag] == nil {
+		p.subscribers[personaTag] = map[*privateEventSubscriber]struct{}{}
+	}
+	p.subscribers[personaTag][sub] = struct{}{}
+}
+
+func (p *PrivateEventSubscribers) remove(personaTag string, sub *privateEventSubscriber) {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	delete(p.subscribers[personaTag], sub)
+	if len(p.subscribers[personaTag]) == 0 {
+		delete(p.subscribers, personaTag)
+	}
+}
+
 func WebSocketUpgrader(c *fiber.Ctx) error {
 	// IsWebSocketUpgrade returns true if the client
 	// requested upgrade to the WebSocket protocol.
@@ -1,27 +1,81 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
+
+	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/cardinal/worldstage"
 )
 
+var ErrWorldNotRunning = errors.New("world is not running")
+
 type GetHealthResponse struct {
 	IsServerRunning   bool `json:"isServerRunning"`
 	IsGameLoopRunning bool `json:"isGameLoopRunning"`
+	// IsReady is true if the world is running and can reach Redis, which is when /ready returns 200.
+	IsReady bool `json:"isReady"`
+	types.HealthStatus
 }
 
 // GetHealth godoc
 //
 //	@Summary      Retrieves the status of the server and game loop
-//	@Description  Retrieves the status of the server and game loop
+//	@Description  Retrieves the stage of the world, its last tick, the connectivity of Redis and the base shard, and
+//	@Description  the progress of its recovery. Returns 503 once the world is shutting down.
 //	@Produce      application/json
 //	@Success      200  {object}  GetHealthResponse  "Server and game loop status"
+//	@Failure      503  {object}  GetHealthResponse  "World is shutting down"
 //	@Router       /health [get]
-func GetHealth() func(c *fiber.Ctx) error {
+func GetHealth(world servertypes.ProviderWorld) func(c *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		res := newHealthResponse(world, ctx)
+		if res.Stage == string(worldstage.ShuttingDown) || res.Stage == string(worldstage.ShutDown) {
+			ctx.Status(fiber.StatusServiceUnavailable)
+		}
+		return ctx.JSON(res)
+	}
+}
+
+// GetReady godoc
+//
+//	@Summary      Retrieves whether the world is ready to serve requests
+//	@Description  Returns 200 once the world has recovered from the base shard and is running, and can reach Redis.
+//	@Description  Returns 503 otherwise, e.g. while the world is still replaying the transactions of the base shard.
+//	@Produce      application/json
+//	@Success      200  {object}  GetHealthResponse  "World is ready"
+//	@Failure      503  {object}  GetHealthResponse  "World is not ready"
+//	@Router       /ready [get]
+func GetReady(world servertypes.ProviderWorld) func(c *fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		res := newHealthResponse(world, ctx)
+		if !res.IsReady {
+			ctx.Status(fiber.StatusServiceUnavailable)
+		}
+		return ctx.JSON(res)
+	}
+}
+
+// RequireRunning is the middleware of the routes that need the world to be running. Transactions sent while the world
+// recovers from the base shard would otherwise be executed along with the replayed ticks.
+func RequireRunning(world servertypes.ProviderWorld) func(*fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
-		return ctx.JSON(GetHealthResponse{
-			IsServerRunning: true,
-			// TODO(scott): reconsider whether we need this. Intuitively server running implies game loop running.
-			IsGameLoopRunning: true,
-		})
+		if !world.IsGameRunning() {
+			return fiber.NewError(fiber.StatusServiceUnavailable, ErrWorldNotRunning.Error())
+		}
+		return ctx.Next()
+	}
+}
+
+func newHealthResponse(world servertypes.ProviderWorld, ctx *fiber.Ctx) GetHealthResponse {
+	health := world.Health(ctx.UserContext())
+	isGameLoopRunning := health.Stage == string(worldstage.Running)
+	return GetHealthResponse{
+		IsServerRunning:   true,
+		IsGameLoopRunning: isGameLoopRunning,
+		IsReady:           isGameLoopRunning && health.Redis.Connected,
+		HealthStatus:      health,
 	}
 }
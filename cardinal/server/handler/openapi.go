@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+const openAPIVersion = "3.1.0"
+
+// OpenAPIDocument is an OpenAPI document describing the routes of the messages and queries registered in a world.
+type OpenAPIDocument struct {
+	OpenAPI    string                    `json:"openapi"`
+	Info       OpenAPIInfo               `json:"info"`
+	Paths      map[string]map[string]any `json:"paths"`
+	Components map[string]map[string]any `json:"components"`
+}
+
+// OpenAPIInfo is the info object of an OpenAPIDocument.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+// GetOpenAPI godoc
+//
+//	@Summary      Retrieves the OpenAPI document of the registered messages and queries
+//	@Description  Retrieves an OpenAPI 3.1 document generated from the messages, queries and components registered in
+//	@Description  the world. Unlike the static swagger document, it describes the request body of every message and
+//	@Description  query, and the schema of every component.
+//	@Produce      application/json
+//	@Success      200  {object}  object  "OpenAPI document"
+//	@Router       /openapi.json [get]
+func GetOpenAPI(
+	world servertypes.ProviderWorld,
+	components []types.ComponentMetadata,
+	messages []types.Message,
+) func(*fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		doc, err := NewOpenAPIDocument(world, components, messages)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to build OpenAPI document: "+err.Error())
+		}
+		return ctx.JSON(doc)
+	}
+}
+
+// NewOpenAPIDocument builds the OpenAPI document of the given components and messages, and of the queries registered
+// in the world. Message and query bodies are described from their field information, while components are described
+// by their JSON schema.
+func NewOpenAPIDocument(
+	world servertypes.ProviderWorld,
+	components []types.ComponentMetadata,
+	messages []types.Message,
+) (*OpenAPIDocument, error) {
+	schemas := map[string]any{
+		"Transaction":             transactionSchema(),
+		"PostTransactionResponse": postTransactionResponseSchema(),
+		"ReceiptEntry":            receiptEntrySchema(),
+	}
+	for _, component := range components {
+		schema, err := componentSchema(component)
+		if err != nil {
+			return nil, err
+		}
+		schemas[component.Name()] = schema
+	}
+
+	paths := map[string]map[string]any{}
+	for _, msg := range messages {
+		url := utils.GetTxURL(msg.Group(), msg.Name())
+		paths[url] = map[string]any{"post": messageOperation(msg, url)}
+		for _, previous := range msg.PreviousVersions() {
+			url = utils.GetVersionedTxURL(previous.Group(), previous.Version(), previous.Name())
+			paths[url] = map[string]any{"post": messageOperation(previous, url)}
+		}
+	}
+	for _, query := range world.BuildQueryFields() {
+		paths[query.URL] = map[string]any{"post": queryOperation(query)}
+	}
+
+	return &OpenAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: OpenAPIInfo{
+			Title:       world.Namespace(),
+			Description: "Messages and queries of the " + world.Namespace() + " world",
+			Version:     "0.0.1",
+		},
+		Paths:      paths,
+		Components: map[string]map[string]any{"schemas": schemas},
+	}, nil
+}
+
+// FieldInformationSchema converts the field information of a message or query, as returned by
+// types.GetFieldInformation, to a JSON schema.
+func FieldInformationSchema(fields map[string]any) map[string]any {
+	properties := make(map[string]any, len(fields))
+	for name, field := range fields {
+		name, _, _ = strings.Cut(name, ",")
+		if name == "-" {
+			continue
+		}
+		if nested, ok := field.(map[string]any); ok {
+			properties[name] = FieldInformationSchema(nested)
+			continue
+		}
+		goType, _ := field.(string)
+		properties[name] = goTypeSchema(goType)
+	}
+	return map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// goTypeSchema returns the JSON schema of the JSON encoding of a Go type, given the name reflect gives the type.
+// Named types that aren't builtin are described by their name only, as their underlying type is unknown.
+func goTypeSchema(goType string) map[string]any {
+	switch {
+	case goType == "string":
+		return map[string]any{"type": "string"}
+	case goType == "bool":
+		return map[string]any{"type": "boolean"}
+	case goType == "float32" || goType == "float64":
+		return map[string]any{"type": "number"}
+	case goType == "time.Time":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case goType == "[]uint8" || goType == "[]byte":
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case isGoIntegerType(goType):
+		return map[string]any{"type": "integer"}
+	case strings.HasPrefix(goType, "*"):
+		return goTypeSchema(goType[1:])
+	case strings.HasPrefix(goType, "[]"):
+		return map[string]any{"type": "array", "items": goTypeSchema(goType[2:])}
+	case strings.HasPrefix(goType, "["):
+		// Arrays, e.g. [4]int
+		if _, elem, ok := strings.Cut(goType, "]"); ok {
+			return map[string]any{"type": "array", "items": goTypeSchema(elem)}
+		}
+	case strings.HasPrefix(goType, "map["):
+		if _, elem, ok := strings.Cut(goType, "]"); ok {
+			return map[string]any{"type": "object", "additionalProperties": goTypeSchema(elem)}
+		}
+	}
+	return map[string]any{"x-go-type": goType}
+}
+
+func isGoIntegerType(goType string) bool {
+	switch strings.TrimPrefix(goType, "u") {
+	case "int", "int8", "int16", "int32", "int64":
+		return true
+	}
+	return goType == "types.EntityID"
+}
+
+// componentSchema returns the JSON schema of a component, with its references rewritten to point into the components
+// of the OpenAPI document.
+func componentSchema(component types.ComponentMetadata) (map[string]any, error) {
+	var schema map[string]any
+	if err := json.Unmarshal(component.GetSchema(), &schema); err != nil {
+		return nil, err
+	}
+	delete(schema, "$schema")
+	delete(schema, "$id")
+	rewriteRefs(schema, "#/$defs/", "#/components/schemas/"+component.Name()+"/$defs/")
+	return schema, nil
+}
+
+func rewriteRefs(node any, from, to string) {
+	switch n := node.(type) {
+	case map[string]any:
+		for key, value := range n {
+			if ref, ok := value.(string); ok && key == "$ref" && strings.HasPrefix(ref, from) {
+				n[key] = to + strings.TrimPrefix(ref, from)
+				continue
+			}
+			rewriteRefs(value, from, to)
+		}
+	case []any:
+		for _, value := range n {
+			rewriteRefs(value, from, to)
+		}
+	}
+}
+
+func messageOperation(msg types.Message, url string) map[string]any {
+	body := map[string]any{
+		"allOf": []any{
+			map[string]any{"$ref": "#/components/schemas/Transaction"},
+			map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"body": FieldInformationSchema(msg.GetInFieldInformation())},
+			},
+		},
+	}
+	description := "Submits a transaction containing the " + msg.FullName() + " message"
+	if msg.Version() > 0 {
+		description += " (version " + strconv.Itoa(msg.Version()) + ")"
+	}
+	if msg.IsSystemOnly() {
+		description += ". It must be sent with a system transaction"
+	}
+	return map[string]any{
+		"operationId": operationID(url),
+		"summary":     "Submits a " + msg.FullName() + " message",
+		"description": description,
+		"tags":        []string{"messages"},
+		"requestBody": jsonBody(body),
+		"responses": map[string]any{
+			"200": jsonResponse("Transaction hash and tick", map[string]any{
+				"$ref": "#/components/schemas/PostTransactionResponse",
+			}),
+		},
+	}
+}
+
+func queryOperation(query types.FieldDetail) map[string]any {
+	return map[string]any{
+		"operationId": operationID(query.URL),
+		"summary":     "Executes the " + query.Name + " query",
+		"tags":        []string{"queries"},
+		"requestBody": jsonBody(FieldInformationSchema(query.Fields)),
+		"responses": map[string]any{
+			"200": jsonResponse("Results of the executed query", map[string]any{"type": "object"}),
+		},
+	}
+}
+
+// operationID derives the ID of an operation from its URL, e.g. tx.game.move for /tx/game/move and tx.game.v1.move
+// for /tx/game/v1/move.
+func operationID(url string) string {
+	return strings.ReplaceAll(strings.TrimPrefix(url, "/"), "/", ".")
+}
+
+func jsonBody(schema any) map[string]any {
+	return map[string]any{
+		"required": true,
+		"content":  map[string]any{fiber.MIMEApplicationJSON: map[string]any{"schema": schema}},
+	}
+}
+
+func jsonResponse(description string, schema any) map[string]any {
+	return map[string]any{
+		"description": description,
+		"content":     map[string]any{fiber.MIMEApplicationJSON: map[string]any{"schema": schema}},
+	}
+}
+
+func transactionSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"personaTag": map[string]any{"type": "string"},
+			"namespace":  map[string]any{"type": "string"},
+			"nonce":      map[string]any{"type": "integer"},
+			"signature":  map[string]any{"type": "string", "description": "hex encoded signature"},
+			"hash":       map[string]any{"type": "string", "description": "hex encoded hash of the transaction"},
+			"body":       map[string]any{"type": "object", "description": "the message of the transaction"},
+			"targetTick": map[string]any{"type": "integer"},
+			"expiryTick": map[string]any{"type": "integer"},
+		},
+		"required": []string{"personaTag", "namespace", "nonce", "signature", "body"},
+	}
+}
+
+func postTransactionResponseSchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"TxHash":  map[string]any{"type": "string"},
+			"Tick":    map[string]any{"type": "integer"},
+			"Receipt": map[string]any{"$ref": "#/components/schemas/ReceiptEntry"},
+		},
+		"required": []string{"TxHash", "Tick"},
+	}
+}
+
+func receiptEntrySchema() map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"txHash":      map[string]any{"type": "string"},
+			"tick":        map[string]any{"type": "integer"},
+			"personaTag":  map[string]any{"type": "string"},
+			"messageName": map[string]any{"type": "string"},
+			"result":      map[string]any{},
+			"errors":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+	}
+}
@@ -23,7 +23,17 @@ import (
 func PostQuery(world servertypes.ProviderWorld) func(*fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		ctx.Set("Content-Type", "application/json")
-		resBz, err := world.HandleQuery(ctx.Params("group"), ctx.Params("name"), ctx.Body())
+		version, ok := versionParam(ctx)
+		if !ok {
+			return fiber.NewError(fiber.StatusNotFound, "query not found")
+		}
+		var resBz []byte
+		var err error
+		if version == 0 {
+			resBz, err = world.HandleQuery(ctx.Params("group"), ctx.Params("name"), ctx.Body())
+		} else {
+			resBz, err = world.HandleVersionedQuery(ctx.Params("group"), ctx.Params("name"), version, ctx.Body())
+		}
 		if eris.Is(err, types.ErrQueryNotFound) {
 			return fiber.NewError(fiber.StatusNotFound, "query not found")
 		} else if err != nil {
@@ -32,3 +42,23 @@ func PostQuery(world servertypes.ProviderWorld) func(*fiber.Ctx) error {
 		return ctx.Send(resBz)
 	}
 }
+
+// NOTE: duplication for cleaner swagger docs
+// PostQuery godoc
+//
+//	@Summary      Executes a version of a query
+//	@Description  Executes the given version of a query. Older versions of a query are served as long as they remain
+//	@Description  registered.
+//	@Accept       application/json
+//	@Produce      application/json
+//	@Param        queryGroup    path      string  true  "Query group"
+//	@Param        queryVersion  path      string  true  "Version of the query, e.g. v2"
+//	@Param        queryName     path      string  true  "Name of a registered query"
+//	@Param        queryBody     body      object  true  "Query to be executed"
+//	@Success      200           {object}  object  "Results of the executed query"
+//	@Failure      400           {string}  string  "Invalid request parameters"
+//	@Failure      404           {string}  string  "Query or query version not found"
+//	@Router       /query/{queryGroup}/{queryVersion}/{queryName} [post]
+func PostVersionedQuery(world servertypes.ProviderWorld) func(*fiber.Ctx) error {
+	return PostQuery(world)
+}
@@ -1,31 +1,54 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 
-	"pkg.world.dev/world-engine/cardinal/server/types"
+	"pkg.world.dev/world-engine/cardinal/receipt"
+	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+	"pkg.world.dev/world-engine/cardinal/types"
 )
 
+// MaxReceiptListTicks is the largest number of ticks whose receipts are returned by a single request to
+// /query/receipts/list.
+const MaxReceiptListTicks = 100
+
+// MaxPendingListSize is the largest number of pending transactions returned by a single request to
+// /query/receipts/list.
+const MaxPendingListSize = 100
+
+// ListTxReceiptsRequest is the request body for /query/receipts/list. When PersonaTag or MessageName are set, only the
+// receipts of transactions sent by that persona or containing that message (in the form <group>.<name>) are returned.
+// The filters apply to the pending transactions too.
 type ListTxReceiptsRequest struct {
-	StartTick uint64 `json:"startTick" mapstructure:"startTick"`
+	StartTick   uint64 `json:"startTick"             mapstructure:"startTick"`
+	PersonaTag  string `json:"personaTag,omitempty"  mapstructure:"personaTag"`
+	MessageName string `json:"messageName,omitempty" mapstructure:"messageName"`
 }
 
 // ListTxReceiptsResponse returns the transaction receipts for the given range of ticks. The interval is closed on
 // StartTick and open on EndTick: i.e. [StartTick, EndTick)
 // Meaning StartTick is included and EndTick is not. To iterate over all ticks in the future, use the returned
-// EndTick as the StartTick in the next request. If StartTick == EndTick, the receipts list will be empty.
+// EndTick as the StartTick in the next request. If StartTick == EndTick, the receipts list will be empty. At most
+// MaxReceiptListTicks ticks are returned, so EndTick can be before the current tick.
+// Pending contains the transactions that have been accepted but are waiting for their target tick to be executed. At
+// most MaxPendingListSize pending transactions are returned, those with the earliest target ticks first.
 type ListTxReceiptsResponse struct {
 	StartTick uint64         `json:"startTick"`
 	EndTick   uint64         `json:"endTick"`
 	Receipts  []ReceiptEntry `json:"receipts"`
+	Pending   []PendingEntry `json:"pending"`
 }
 
 // ReceiptEntry represents a single transaction receipt. It contains an ID, a result, and a list of errors.
 type ReceiptEntry struct {
-	TxHash string   `json:"txHash"`
-	Tick   uint64   `json:"tick"`
-	Result any      `json:"result"`
-	Errors []string `json:"errors"`
+	TxHash      string   `json:"txHash"`
+	Tick        uint64   `json:"tick"`
+	PersonaTag  string   `json:"personaTag"`
+	MessageName string   `json:"messageName"`
+	Result      any      `json:"result"`
+	Errors      []string `json:"errors"`
 }
 
 // GetReceipts godoc
@@ -38,45 +61,135 @@ type ReceiptEntry struct {
 //	@Success      200                    {object}  ListTxReceiptsResponse "List of receipts"
 //	@Failure      400                    {string}  string                 "Invalid request body"
 //	@Router       /query/receipts/list [post]
-func GetReceipts(world types.ProviderWorld) func(*fiber.Ctx) error {
+func GetReceipts(world servertypes.ProviderWorld) func(*fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
 		req := new(ListTxReceiptsRequest)
 		if err := ctx.BodyParser(req); err != nil {
 			return err
 		}
-		reply := ListTxReceiptsResponse{}
-		reply.EndTick = world.CurrentTick()
-		size := world.ReceiptHistorySize()
-		if size > reply.EndTick {
-			reply.StartTick = 0
-		} else {
-			reply.StartTick = reply.EndTick - size
-		}
-		// StartTick and EndTick are now at the largest possible range of ticks.
-		// Check to see if we should narrow down the range at all.
-		if req.StartTick > reply.EndTick {
-			// User is asking for ticks in the future.
-			reply.StartTick = reply.EndTick
-		} else if req.StartTick > reply.StartTick {
-			reply.StartTick = req.StartTick
+		reply, err := ListReceipts(world, req)
+		if err != nil {
+			return err
 		}
+		return ctx.JSON(reply)
+	}
+}
 
-		for t := reply.StartTick; t < reply.EndTick; t++ {
-			currReceipts, err := world.GetTransactionReceiptsForTick(t)
-			if err != nil || len(currReceipts) == 0 {
-				continue
+// ListReceipts returns the receipts of the ticks since the start tick of the request that are still available. Errors
+// are returned as *fiber.Error, whose code is the HTTP status of the error.
+func ListReceipts(world servertypes.ProviderWorld, req *ListTxReceiptsRequest) (*ListTxReceiptsResponse, error) {
+	reply := &ListTxReceiptsResponse{}
+	reply.EndTick = world.CurrentTick()
+	size := world.ReceiptHistorySize()
+	if size > reply.EndTick {
+		reply.StartTick = 0
+	} else {
+		reply.StartTick = reply.EndTick - size
+	}
+	// StartTick and EndTick are now at the largest possible range of ticks.
+	// Check to see if we should narrow down the range at all.
+	if req.StartTick > reply.EndTick {
+		// User is asking for ticks in the future.
+		reply.StartTick = reply.EndTick
+	} else if req.StartTick > reply.StartTick {
+		reply.StartTick = req.StartTick
+	}
+	reply.EndTick = min(reply.EndTick, reply.StartTick+MaxReceiptListTicks)
+
+	receipts, err := world.GetTransactionReceiptsForTicks(reply.StartTick, reply.EndTick)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "failed to get receipts: "+err.Error())
+	}
+	for t := reply.StartTick; t < reply.EndTick; t++ {
+		for _, r := range receipts[t] {
+			if req.matches(r.PersonaTag, r.MsgName) {
+				reply.Receipts = append(reply.Receipts, NewReceiptEntry(r, t))
 			}
-			for _, r := range currReceipts {
-				reply.Receipts = append(reply.Receipts, ReceiptEntry{
-					TxHash: string(r.TxHash),
-					Tick:   t,
-					Result: r.Result,
-					Errors: convertErrorsToStrings(r.Errs),
-				})
+		}
+	}
+
+	for _, tx := range world.GetPendingTransactions() {
+		if len(reply.Pending) == MaxPendingListSize {
+			break
+		}
+		msgName := ""
+		if msgType, ok := world.GetMessageByID(tx.MsgID); ok {
+			msgName = msgType.FullName()
+		}
+		if !req.matches(tx.Tx.PersonaTag, msgName) {
+			continue
+		}
+		reply.Pending = append(reply.Pending, PendingEntry{
+			TxHash:     string(tx.TxHash),
+			TargetTick: tx.Tx.TargetTick,
+		})
+	}
+	return reply, nil
+}
+
+// matches reports whether a transaction sent by the given persona, containing the message with the given full name,
+// passes the filters of the request.
+func (r *ListTxReceiptsRequest) matches(personaTag, msgName string) bool {
+	if r.PersonaTag != "" && personaTag != r.PersonaTag {
+		return false
+	}
+	return r.MessageName == "" || msgName == r.MessageName
+}
+
+// GetReceipt godoc
+//
+//	@Summary      Retrieves the receipt of a transaction
+//	@Description  Retrieves the receipt of a transaction by its hash, once the tick it was executed in has completed.
+//	@Description  Transactions that are waiting for their target tick have a 202 status with the target tick instead.
+//	@Produce      application/json
+//	@Param        txHash  path      string        true  "Hash of the transaction"
+//	@Success      200     {object}  ReceiptEntry  "Receipt of the transaction"
+//	@Success      202     {object}  PendingEntry  "Transaction is waiting for its target tick"
+//	@Failure      404     {string}  string        "Receipt not found"
+//	@Router       /receipt/{txHash} [get]
+func GetReceipt(world servertypes.ProviderWorld) func(*fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		txHash := types.TxHash(ctx.Params("txHash"))
+		rec, tick, err := world.GetTransactionReceipt(txHash)
+		if errors.Is(err, receipt.ErrReceiptNotFound) {
+			if pending, ok := FindPendingTransaction(world, txHash); ok {
+				return ctx.Status(fiber.StatusAccepted).JSON(pending)
 			}
+			return fiber.NewError(fiber.StatusNotFound, "receipt not found")
+		} else if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to get receipt: "+err.Error())
+		}
+		return ctx.JSON(NewReceiptEntry(rec, tick))
+	}
+}
+
+// NewReceiptEntry returns the entry of a receipt that was produced in the given tick.
+func NewReceiptEntry(r receipt.Receipt, tick uint64) ReceiptEntry {
+	return ReceiptEntry{
+		TxHash:      string(r.TxHash),
+		Tick:        tick,
+		PersonaTag:  r.PersonaTag,
+		MessageName: r.MsgName,
+		Result:      r.Result,
+		Errors:      convertErrorsToStrings(r.Errs),
+	}
+}
+
+// PendingEntry represents a transaction that will be executed in TargetTick.
+type PendingEntry struct {
+	TxHash     string `json:"txHash"`
+	TargetTick uint64 `json:"targetTick"`
+}
+
+// FindPendingTransaction returns the entry of the transaction with the given hash if it is waiting for its target
+// tick.
+func FindPendingTransaction(world servertypes.ProviderWorld, txHash types.TxHash) (PendingEntry, bool) {
+	for _, tx := range world.GetPendingTransactions() {
+		if tx.TxHash == txHash {
+			return PendingEntry{TxHash: string(tx.TxHash), TargetTick: tx.Tx.TargetTick}, true
 		}
-		return ctx.JSON(reply)
 	}
+	return PendingEntry{}, false
 }
 
 func convertErrorsToStrings(errs []error) []string {
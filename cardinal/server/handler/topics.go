@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gofiber/contrib/socketio"
+	"github.com/rotisserie/eris"
+	"github.com/rs/zerolog/log"
+
+	"pkg.world.dev/world-engine/cardinal/receipt"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+// Prefixes of the topics an events websocket connection can subscribe to. A topic is a prefix followed by a value.
+const (
+	// EventTopicPrefix is followed by an event type. It matches the events whose "type" field is the event type.
+	EventTopicPrefix = "event:"
+	// MessageTopicPrefix is followed by the full name of a message, e.g. game.attack. It matches the receipts of the
+	// transactions that contain the message.
+	MessageTopicPrefix = "message:"
+	// ReceiptTopicPrefix is followed by a transaction hash. It matches the receipt of the transaction.
+	ReceiptTopicPrefix = "receipt:"
+	// EntityTopicPrefix is followed by an entity ID. It matches the changes to the components of the entity.
+	EntityTopicPrefix = "entity:"
+)
+
+var ErrInvalidTopic = errors.New("invalid topic")
+
+// SubscriptionRequest is sent by an events websocket connection to change the topics it is subscribed to.
+type SubscriptionRequest struct {
+	Subscribe   []string `json:"subscribe,omitempty"`
+	Unsubscribe []string `json:"unsubscribe,omitempty"`
+}
+
+// SubscriptionResponse is sent to an events websocket connection after it sends a SubscriptionRequest. Topics are the
+// topics the connection is subscribed to, and Error is set if the request was rejected.
+type SubscriptionResponse struct {
+	Topics []string `json:"topics"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// TopicEvents are the results of a tick that match the topics an events websocket connection is subscribed to.
+type TopicEvents struct {
+	Tick         uint64                    `json:"tick"`
+	Events       []json.RawMessage         `json:"events,omitempty"`
+	Receipts     []receipt.Receipt         `json:"receipts,omitempty"`
+	StateChanges []types.EntityStateChange `json:"stateChanges,omitempty"`
+}
+
+// TickResults are the results of a tick that events websocket connections can subscribe to topics of.
+type TickResults interface {
+	GetTick() uint64
+	GetReceipts() []receipt.Receipt
+	GetEvents() [][]byte
+	GetStateChanges() []types.EntityStateChange
+}
+
+// EventSubscribers keeps track of the events websocket connections and the topics they are subscribed to.
+// Connections that never sent a SubscriptionRequest receive every event that is broadcast, while connections that did
+// only receive the TopicEvents that match their topics.
+type EventSubscribers struct {
+	mux   sync.RWMutex
+	conns map[string]*eventSubscriber
+	// nextBroadcastTick is the tick after the last tick whose results were broadcast. It is set while mux is read
+	// locked, so it does not change while mux is locked.
+	nextBroadcastTick atomic.Uint64
+}
+
+type eventSubscriber struct {
+	kws        *socketio.Websocket
+	subscribed bool
+	topics     map[string]struct{}
+	// resumedTick is the tick the connection resumed from after it was sent the history of the ticks before it. The
+	// results of those ticks are not sent again.
+	resumedTick uint64
+	// resuming is set while the connection is being sent the history, during which nothing is broadcast to it.
+	resuming bool
+}
+
+func NewEventSubscribers() *EventSubscribers {
+	return &EventSubscribers{
+		conns: map[string]*eventSubscriber{},
+	}
+}
+
+// Broadcast sends the given event to the connections that are not subscribed to topics. If the event is the
+// TickResults of a tick, the results that match the topics of the other connections are sent to them.
+func (e *EventSubscribers) Broadcast(event any) error {
+	eventBz, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	results, isTickResults := event.(TickResults)
+
+	e.mux.RLock()
+	defer e.mux.RUnlock()
+	if isTickResults {
+		e.nextBroadcastTick.Store(results.GetTick() + 1)
+	}
+	for _, sub := range e.conns {
+		if sub.resuming || (isTickResults && results.GetTick() < sub.resumedTick) {
+			continue
+		}
+		if !sub.subscribed {
+			sub.kws.Emit(eventBz)
+			continue
+		}
+		if !isTickResults {
+			continue
+		}
+		topicEvents, ok := sub.match(results)
+		if !ok {
+			continue
+		}
+		bz, err := json.Marshal(topicEvents)
+		if err != nil {
+			return err
+		}
+		sub.kws.Emit(bz)
+	}
+	return nil
+}
+
+// SubscribedEntities returns the IDs of the entities that any connection is subscribed to.
+func (e *EventSubscribers) SubscribedEntities() []types.EntityID {
+	e.mux.RLock()
+	defer e.mux.RUnlock()
+	var ids []types.EntityID
+	for _, sub := range e.conns {
+		for topic := range sub.topics {
+			if id, ok := strings.CutPrefix(topic, EntityTopicPrefix); ok {
+				// Topics are validated when they are subscribed to.
+				num, _ := strconv.ParseUint(id, 10, 64)
+				ids = append(ids, types.EntityID(num))
+			}
+		}
+	}
+	return ids
+}
+
+// add registers a connection. If history is not nil, the results of the past ticks it returns from the given tick
+// onward are sent to the connection first. The history is loaded without blocking broadcasts, so it is loaded again
+// from where it ended if it was cut off at MaxEventHistoryTicks ticks or a tick it does not include was broadcast in
+// the meantime. This way the connection neither misses nor receives the results of a tick twice.
+//
+// The connection is registered while the history is sent, so that it is removed if it disconnects in the meantime, in
+// which case sending the history stops.
+func (e *EventSubscribers) add(
+	kws *socketio.Websocket, fromTick uint64, history func(fromTick uint64) (EventHistoryResponse, error),
+) error {
+	sub := &eventSubscriber{
+		kws:         kws,
+		subscribed:  false,
+		topics:      map[string]struct{}{},
+		resumedTick: 0,
+		resuming:    history != nil,
+	}
+	uuid := kws.GetUUID()
+	e.mux.Lock()
+	e.conns[uuid] = sub
+	e.mux.Unlock()
+	if history == nil {
+		return nil
+	}
+
+	for {
+		res, err := history(fromTick)
+		if err != nil {
+			e.remove(uuid)
+			return err
+		}
+		for _, results := range res.Ticks {
+			bz, err := json.Marshal(results)
+			if err != nil {
+				e.remove(uuid)
+				return err
+			}
+			kws.Emit(bz)
+		}
+
+		e.mux.Lock()
+		if e.conns[uuid] != sub {
+			// The connection was closed.
+			e.mux.Unlock()
+			return nil
+		}
+		cutOff := res.EndTick-res.StartTick == MaxEventHistoryTicks
+		if !cutOff && e.nextBroadcastTick.Load() <= res.EndTick {
+			sub.resumedTick = res.EndTick
+			sub.resuming = false
+			e.mux.Unlock()
+			return nil
+		}
+		e.mux.Unlock()
+		fromTick = res.EndTick
+	}
+}
+
+// Count returns the number of open connections.
+func (e *EventSubscribers) Count() int {
+	e.mux.RLock()
+	defer e.mux.RUnlock()
+	return len(e.conns)
+}
+
+func (e *EventSubscribers) remove(uuid string) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	delete(e.conns, uuid)
+}
+
+// handleMessage changes the topics of a connection based on the SubscriptionRequest it sent.
+func (e *EventSubscribers) handleMessage(uuid string, data []byte) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	sub, ok := e.conns[uuid]
+	if !ok {
+		return
+	}
+
+	var res SubscriptionResponse
+	var req SubscriptionRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		res.Error = "failed to parse subscription request: " + err.Error()
+	} else if err = validateTopics(req.Subscribe); err != nil {
+		res.Error = err.Error()
+	} else {
+		sub.subscribed = true
+		for _, topic := range req.Subscribe {
+			sub.topics[topic] = struct{}{}
+		}
+		for _, topic := range req.Unsubscribe {
+			delete(sub.topics, topic)
+		}
+	}
+
+	res.Topics = make([]string, 0, len(sub.topics))
+	for topic := range sub.topics {
+		res.Topics = append(res.Topics, topic)
+	}
+	bz, err := json.Marshal(res)
+	if err != nil {
+		log.Err(err).Msg("failed to marshal subscription response")
+		return
+	}
+	sub.kws.Emit(bz)
+}
+
+// match returns the results of a tick that match the topics of the connection. False is returned if nothing matches.
+func (s *eventSubscriber) match(results TickResults) (TopicEvents, bool) {
+	topicEvents := TopicEvents{Tick: results.GetTick()}
+	for _, event := range results.GetEvents() {
+		var typed struct {
+			Type string `json:"type"`
+		}
+		// Events that are not JSON objects do not have a type.
+		if err := json.Unmarshal(event, &typed); err != nil || typed.Type == "" {
+			continue
+		}
+		if s.hasTopic(EventTopicPrefix + typed.Type) {
+			topicEvents.Events = append(topicEvents.Events, event)
+		}
+	}
+	for _, rec := range results.GetReceipts() {
+		if s.hasTopic(ReceiptTopicPrefix+string(rec.TxHash)) || s.hasTopic(MessageTopicPrefix+rec.MsgName) {
+			topicEvents.Receipts = append(topicEvents.Receipts, rec)
+		}
+	}
+	for _, change := range results.GetStateChanges() {
+		if s.hasTopic(EntityTopicPrefix + strconv.FormatUint(uint64(change.ID), 10)) {
+			topicEvents.StateChanges = append(topicEvents.StateChanges, change)
+		}
+	}
+	ok := len(topicEvents.Events) > 0 || len(topicEvents.Receipts) > 0 || len(topicEvents.StateChanges) > 0
+	return topicEvents, ok
+}
+
+func (s *eventSubscriber) hasTopic(topic string) bool {
+	_, ok := s.topics[topic]
+	return ok
+}
+
+// validateTopics checks that each topic has a known prefix followed by a valid value.
+func validateTopics(topics []string) error {
+	for _, topic := range topics {
+		var value string
+		var ok bool
+		for _, prefix := range []string{EventTopicPrefix, MessageTopicPrefix, ReceiptTopicPrefix, EntityTopicPrefix} {
+			if value, ok = strings.CutPrefix(topic, prefix); ok {
+				break
+			}
+		}
+		if !ok || value == "" {
+			return eris.Wrapf(ErrInvalidTopic, "%q", topic)
+		}
+		if strings.HasPrefix(topic, EntityTopicPrefix) {
+			if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+				return eris.Wrapf(ErrInvalidTopic, "%q has an invalid entity id", topic)
+			}
+		}
+	}
+	return nil
+}
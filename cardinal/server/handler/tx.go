@@ -1,14 +1,19 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rotisserie/eris"
 
+	"pkg.world.dev/world-engine/cardinal/persona"
 	personaMsg "pkg.world.dev/world-engine/cardinal/persona/msg"
+	"pkg.world.dev/world-engine/cardinal/receipt"
 	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+	"pkg.world.dev/world-engine/cardinal/txpool"
 	"pkg.world.dev/world-engine/cardinal/types"
 	"pkg.world.dev/world-engine/sign"
 )
@@ -18,12 +23,60 @@ var (
 	ErrWrongNamespace             = errors.New("incorrect namespace")
 	ErrSystemTransactionRequired  = errors.New("system transaction required")
 	ErrSystemTransactionForbidden = errors.New("system transaction forbidden")
+	ErrSystemSignerNotSet         = errors.New("system signer address is not set")
+	ErrEmptyBatch                 = errors.New("batch must contain at least one transaction")
+	ErrBatchPersonaTagMismatch    = errors.New("all transactions in a batch must use the same persona tag")
+	ErrBatchTargetTickMismatch    = errors.New("all transactions in a batch must use the same target tick")
+	ErrBatchExpiryTickMismatch    = errors.New("all transactions in a batch must use the same expiry tick")
+	ErrBatchSignerMismatch        = errors.New("all transactions in a batch must be signed by the same signer")
+	ErrExpiryBeforeTargetTick     = errors.New("expiry tick must not be before the target tick")
 )
 
-// PostTransactionResponse is the HTTP response for a successful transaction submission
+const (
+	// DefaultWaitTimeout is how long a transaction submission with wait=true waits for the receipt of the transaction
+	// when no timeout is given.
+	DefaultWaitTimeout = 10 * time.Second
+	// MaxWaitTimeout is the longest a transaction submission with wait=true can wait for the receipt of the
+	// transaction.
+	MaxWaitTimeout = time.Minute
+)
+
+// PostTransactionResponse is the HTTP response for a successful transaction submission. Receipt is only set when the
+// submission waited for the transaction to be executed.
 type PostTransactionResponse struct {
-	TxHash string
-	Tick   uint64
+	TxHash  string
+	Tick    uint64
+	Receipt *ReceiptEntry `json:",omitempty"`
+}
+
+// SimulateTransactionResponse is the HTTP response for a simulated transaction. Receipt is the receipt the transaction
+// would get, and StateChanges are the changes the tick would make to the game state.
+type SimulateTransactionResponse struct {
+	Tick         uint64
+	Receipt      ReceiptEntry
+	StateChanges []types.EntityStateChange
+}
+
+// PostBatchTransactionRequest is the HTTP request for submitting multiple transactions that must be executed in the
+// same tick.
+type PostBatchTransactionRequest struct {
+	Transactions []BatchTransaction `json:"transactions"`
+}
+
+// BatchTransaction is a single transaction of a batch, along with the message it contains.
+type BatchTransaction struct {
+	Group string `json:"group"`
+	Name  string `json:"name"`
+	// Version is the version of the message. The latest version is used if it is not set.
+	Version int         `json:"version,omitempty"`
+	Tx      Transaction `json:"tx"`
+}
+
+// PostBatchTransactionResponse is the HTTP response for a successful batch submission. TxHashes are in the same order
+// as the submitted transactions.
+type PostBatchTransactionResponse struct {
+	TxHashes []string
+	Tick     uint64
 }
 
 type Transaction = sign.Transaction
@@ -31,64 +84,198 @@ type Transaction = sign.Transaction
 // PostTransaction godoc
 //
 //	@Summary      Submits a transaction
-//	@Description  Submits a transaction
+//	@Description  Submits a transaction. If wait is true, the request blocks until the transaction has been executed
+//	@Description  and its receipt is returned inline. If the tick of the transaction completes without a receipt for
+//	@Description  it, or the timeout expires first, the transaction hash and tick are returned with a 202 status.
 //	@Accept       application/json
 //	@Produce      application/json
-//	@Param        txGroup  path      string                   true  "Message group"
-//	@Param        txName   path      string                   true  "Name of a registered message"
-//	@Param        txBody   body      Transaction              true  "Transaction details & message to be submitted"
-//	@Success      200      {object}  PostTransactionResponse  "Transaction hash and tick"
-//	@Failure      400      {string}  string                   "Invalid request parameter"
+//	@Param        txGroup  path      string                   true   "Message group"
+//	@Param        txName   path      string                   true   "Name of a registered message"
+//	@Param        txBody   body      Transaction              true   "Transaction details & message to be submitted"
+//	@Param        wait     query     bool                     false  "Wait for the transaction to be executed"
+//	@Param        timeout  query     int                      false  "Milliseconds to wait (default 10000)"
+//	@Success      200      {object}  PostTransactionResponse  "Transaction hash and tick, and receipt if waited for"
+//	@Success      202      {object}  PostTransactionResponse  "Transaction hash and tick, receipt not yet available"
+//	@Failure      400      {string}  string                   "Invalid request parameter or message failed validation"
+//	@Failure      403      {string}  string                   "Persona is not authorized to send the message"
 //	@Router       /tx/{txGroup}/{txName} [post]
 func PostTransaction(
 	world servertypes.ProviderWorld, msgs map[string]map[string]types.Message, disableSigVerification bool,
 ) func(*fiber.Ctx) error {
 	return func(ctx *fiber.Ctx) error {
-		msgType, ok := msgs[ctx.Params("group")][ctx.Params("name")]
+		version, ok := versionParam(ctx)
 		if !ok {
 			return fiber.NewError(fiber.StatusNotFound, "message type not found")
 		}
-
-		// Parse the request body into a sign.Transaction struct
-		tx := new(Transaction)
-		if err := ctx.BodyParser(tx); err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body: "+err.Error())
+		tx, err := parseTransactionBody(ctx)
+		if err != nil {
+			return err
+		}
+		hash, tick, err := SubmitTransaction(
+			world, msgs, ctx.Params("group"), ctx.Params("name"), version, tx, disableSigVerification)
+		if err != nil {
+			return err
 		}
 
-		// Validate the transaction
-		if err := validateTx(tx); err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, "invalid transaction payload: "+err.Error())
+		res := &PostTransactionResponse{
+			TxHash: string(hash),
+			Tick:   tick,
 		}
+		if ctx.QueryBool("wait") {
+			rec, ok, err := waitForReceipt(ctx.Context(), world, hash, tick, waitTimeout(ctx))
+			if err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, "failed to get receipt: "+err.Error())
+			}
+			if !ok {
+				return ctx.Status(fiber.StatusAccepted).JSON(res)
+			}
+			res.Receipt = &rec
+		}
+		return ctx.JSON(res)
+	}
+}
 
-		// Decode the message from the transaction
-		msg, err := msgType.Decode(tx.Body)
+// SubmitTransaction verifies the signature of a transaction containing the given version of a message, validates the
+// message, and adds it to the pool. The latest version of the message is used if version is 0. Errors are returned as
+// *fiber.Error, whose code is the HTTP status of the error.
+func SubmitTransaction(
+	world servertypes.ProviderWorld, msgs map[string]map[string]types.Message, group, name string, version int,
+	tx *Transaction, disableSigVerification bool,
+) (types.TxHash, uint64, error) {
+	msgType, msg, err := decodeTransaction(world, msgs, group, name, version, tx)
+	if err != nil {
+		return "", 0, err
+	}
+
+	signerAddress := ""
+	if !disableSigVerification {
+		signerAddress, err = messageSignerAddress(world, msgType, msg)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, "failed to decode message from transaction")
+			return "", 0, err
 		}
+		signerAddress, err = lookupSignerAndVerifySignature(world, signerAddress, tx)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+	if err = validateMessage(world, msgType, msg, tx); err != nil {
+		return "", 0, err
+	}
+	if !disableSigVerification {
+		// TODO(scott): this should be refactored; it should be the responsibility of the engine tx processor
+		//  to mark the nonce as used once it's included in the tick, not the server.
+		if err = world.UseNonce(signerAddress, tx.Nonce); err != nil {
+			return "", 0, fiber.NewError(fiber.StatusInternalServerError, "failed to use nonce: "+err.Error())
+		}
+	}
 
-		if !disableSigVerification {
-			var signerAddress string
-			// TODO(scott): don't hardcode this
-			if msgType.Name() == "create-persona" {
-				// don't need to check the cast bc we already validated this above
-				createPersonaMsg, _ := msg.(personaMsg.CreatePersona)
-				signerAddress = createPersonaMsg.SignerAddress
-			}
+	// Add the transaction to the engine. The version of the message is kept so that the transaction can be decoded
+	// again when it is recovered from the base shard.
+	// TODO(scott): this should just deal with txpool instead of having to go through engine
+	tick, hashes := world.AddTransactions([]txpool.TxData{{
+		MsgID:      msgType.ID(),
+		Msg:        msg,
+		Tx:         tx,
+		MsgVersion: msgType.Version(),
+	}})
+	return hashes[0], tick, nil
+}
 
-			if err = lookupSignerAndValidateSignature(world, signerAddress, tx); err != nil {
-				return err
-			}
-		}
+// parseTransaction parses the transaction in the request body and decodes the message it contains.
+func parseTransaction(
+	ctx *fiber.Ctx, world servertypes.ProviderWorld, msgs map[string]map[string]types.Message,
+) (types.Message, any, *Transaction, error) {
+	version, ok := versionParam(ctx)
+	if !ok {
+		return nil, nil, nil, fiber.NewError(fiber.StatusNotFound, "message type not found")
+	}
+	tx, err := parseTransactionBody(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	msgType, msg, err := decodeTransaction(world, msgs, ctx.Params("group"), ctx.Params("name"), version, tx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return msgType, msg, tx, nil
+}
 
-		// Add the transaction to the engine
-		// TODO(scott): this should just deal with txpool instead of having to go through engine
-		tick, hash := world.AddTransaction(msgType.ID(), msg, tx)
+// parseTransactionBody parses the request body into a sign.Transaction struct.
+func parseTransactionBody(ctx *fiber.Ctx) (*Transaction, error) {
+	tx := new(Transaction)
+	if err := ctx.BodyParser(tx); err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "failed to parse request body: "+err.Error())
+	}
+	return tx, nil
+}
 
-		return ctx.JSON(&PostTransactionResponse{
-			TxHash: string(hash),
-			Tick:   tick,
-		})
+// decodeTransaction validates the transaction payload and decodes the given version of a message from it. The message
+// itself is validated by validateMessage, once the signature of the transaction has been verified.
+func decodeTransaction(
+	world servertypes.ProviderWorld, msgs map[string]map[string]types.Message, group, name string, version int,
+	tx *Transaction,
+) (types.Message, any, error) {
+	msgType, ok := lookupMessage(msgs, group, name, version)
+	if !ok {
+		return nil, nil, fiber.NewError(fiber.StatusNotFound, "message type not found")
+	}
+
+	// Validate the transaction
+	if err := validateTx(tx); err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "invalid transaction payload: "+err.Error())
+	}
+	if msgType.IsSystemOnly() && !tx.IsSystemTransaction() {
+		return nil, nil, fiber.NewError(fiber.StatusForbidden,
+			"invalid transaction payload: "+ErrSystemTransactionRequired.Error())
+	}
+
+	// Decode the message from the transaction
+	msg, err := msgType.Decode(tx.Body)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, "failed to decode message from transaction")
+	}
+	return msgType, msg, nil
+}
+
+// validateMessage runs the validators of the message, and checks that the persona of the transaction is authorized to
+// send it. Validators can read the game state, so this must only be called once the signature of the transaction has
+// been verified. The transaction is rejected before it uses a nonce or reaches the pool.
+func validateMessage(world servertypes.ProviderWorld, msgType types.Message, msg any, tx *Transaction) error {
+	if err := world.ValidateTransaction(msgType.ID(), msg, tx); err != nil {
+		return fiber.NewError(validationErrorStatus(err), "message validation failed: "+err.Error())
 	}
+	return nil
+}
+
+// waitTimeout returns how long to wait for a receipt based on the timeout query parameter, in milliseconds.
+func waitTimeout(ctx *fiber.Ctx) time.Duration {
+	timeout := time.Duration(ctx.QueryInt("timeout")) * time.Millisecond
+	if timeout <= 0 {
+		return DefaultWaitTimeout
+	}
+	return min(timeout, MaxWaitTimeout)
+}
+
+// waitForReceipt blocks until the tick the given transaction was assigned to has completed, and returns the receipt
+// of the transaction. False is returned if the timeout expires or ctx is done first, or if the transaction has no
+// receipt once its tick has completed, e.g. because its message is not handled by any system.
+func waitForReceipt(
+	ctx context.Context, world servertypes.ProviderWorld, txHash types.TxHash, tick uint64, timeout time.Duration,
+) (ReceiptEntry, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for world.CurrentTick() <= tick {
+		if !world.WaitForNextTickContext(ctx) {
+			return ReceiptEntry{}, false, nil
+		}
+	}
+	rec, recTick, err := world.GetTransactionReceipt(txHash)
+	if errors.Is(err, receipt.ErrReceiptNotFound) {
+		return ReceiptEntry{}, false, nil
+	} else if err != nil {
+		return ReceiptEntry{}, false, err
+	}
+	return NewReceiptEntry(rec, recTick), true, nil
 }
 
 // NOTE: duplication for cleaner swagger docs
@@ -102,6 +289,7 @@ func PostTransaction(
 //	@Param        txBody  body      Transaction              true  "Transaction details & message to be submitted"
 //	@Success      200     {object}  PostTransactionResponse  "Transaction hash and tick"
 //	@Failure      400     {string}  string                   "Invalid request parameter"
+//	@Failure      403     {string}  string                   "Persona is not authorized to send the message"
 //	@Router       /tx/game/{txName} [post]
 func PostGameTransaction(
 	world servertypes.ProviderWorld, msgs map[string]map[string]types.Message, disableSigVerification bool,
@@ -109,6 +297,29 @@ func PostGameTransaction(
 	return PostTransaction(world, msgs, disableSigVerification)
 }
 
+// NOTE: duplication for cleaner swagger docs
+// PostTransaction godoc
+//
+//	@Summary      Submits a transaction for a version of a message
+//	@Description  Submits a transaction for the given version of a message. Messages of older versions are converted
+//	@Description  to the latest version before they are executed.
+//	@Accept       application/json
+//	@Produce      application/json
+//	@Param        txGroup    path      string                   true  "Message group"
+//	@Param        txVersion  path      string                   true  "Version of the message, e.g. v2"
+//	@Param        txName     path      string                   true  "Name of a registered message"
+//	@Param        txBody     body      Transaction              true  "Transaction details & message to be submitted"
+//	@Success      200        {object}  PostTransactionResponse  "Transaction hash and tick"
+//	@Failure      400        {string}  string                   "Invalid request parameter or message failed validation"
+//	@Failure      403        {string}  string                   "Persona is not authorized to send the message"
+//	@Failure      404        {string}  string                   "Message or message version not found"
+//	@Router       /tx/{txGroup}/{txVersion}/{txName} [post]
+func PostVersionedTransaction(
+	world servertypes.ProviderWorld, msgs map[string]map[string]types.Message, disableSigVerification bool,
+) func(*fiber.Ctx) error {
+	return PostTransaction(world, msgs, disableSigVerification)
+}
+
 // NOTE: duplication for cleaner swagger docs
 // PostTransaction godoc
 //
@@ -126,32 +337,255 @@ func PostPersonaTransaction(
 	return PostTransaction(world, msgs, disableSigVerification)
 }
 
-func lookupSignerAndValidateSignature(world servertypes.ProviderWorld, signerAddress string, tx *Transaction) error {
+// PostSimulateTransaction godoc
+//
+//	@Summary      Simulates a transaction
+//	@Description  Runs the systems of the next tick with the transaction as its only transaction, and returns the
+//	@Description  receipt the transaction would get and the state changes the tick would make. Nothing is committed:
+//	@Description  the game state is unchanged, the nonce of the transaction is not used, and no events are emitted.
+//	@Accept       application/json
+//	@Produce      application/json
+//	@Param        txGroup  path      string                       true  "Message group"
+//	@Param        txName   path      string                       true  "Name of a registered message"
+//	@Param        txBody   body      Transaction                  true  "Transaction details & message to be simulated"
+//	@Success      200      {object}  SimulateTransactionResponse  "Simulated tick, receipt, and state changes"
+//	@Failure      400      {string}  string                       "Invalid request or message failed validation"
+//	@Failure      403      {string}  string                       "Persona is not authorized to send the message"
+//	@Failure      404      {string}  string                       "Message not found"
+//	@Failure      429      {string}  string                       "Another transaction is being simulated"
+//	@Failure      500      {string}  string                       "Simulation failed, e.g. because a system panicked"
+//	@Router       /tx/simulate/{txGroup}/{txName} [post]
+func PostSimulateTransaction(
+	world servertypes.ProviderWorld, msgs map[string]map[string]types.Message, disableSigVerification bool,
+) func(*fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		msgType, msg, tx, err := parseTransaction(ctx, world, msgs)
+		if err != nil {
+			return err
+		}
+
+		if !disableSigVerification {
+			signerAddress, err := messageSignerAddress(world, msgType, msg)
+			if err != nil {
+				return err
+			}
+			if _, err = lookupSignerAndVerifySignature(world, signerAddress, tx); err != nil {
+				return err
+			}
+		}
+		if err = validateMessage(world, msgType, msg, tx); err != nil {
+			return err
+		}
+
+		rec, changes, err := world.SimulateTransaction(msgType.ID(), msg, tx)
+		if eris.Is(err, types.ErrSimulationInProgress) {
+			return fiber.NewError(fiber.StatusTooManyRequests, err.Error())
+		}
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "failed to simulate transaction: "+err.Error())
+		}
+		tick := max(world.CurrentTick(), tx.TargetTick)
+		return ctx.JSON(&SimulateTransactionResponse{
+			Tick:         tick,
+			Receipt:      NewReceiptEntry(rec, tick),
+			StateChanges: changes,
+		})
+	}
+}
+
+// PostBatchTransaction godoc
+//
+//	@Summary      Submits a batch of transactions
+//	@Description  Submits a batch of transactions from a single persona that are all executed in the same tick.
+//	@Description  The batch is validated as a whole; if any transaction is invalid, none of them are submitted.
+//	@Accept       application/json
+//	@Produce      application/json
+//	@Param        batch  body      PostBatchTransactionRequest   true  "Transactions to be submitted"
+//	@Success      200    {object}  PostBatchTransactionResponse  "Transaction hashes and tick"
+//	@Failure      400    {string}  string                        "Invalid request parameter"
+//	@Failure      403    {string}  string                        "Persona is not authorized to send a message"
+//	@Router       /tx/batch [post]
+func PostBatchTransaction(
+	world servertypes.ProviderWorld, msgs map[string]map[string]types.Message, disableSigVerification bool,
+) func(*fiber.Ctx) error {
+	return func(ctx *fiber.Ctx) error {
+		req := new(PostBatchTransactionRequest)
+		if err := ctx.BodyParser(req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "failed to parse request body: "+err.Error())
+		}
+		res, err := SubmitTransactionBatch(world, msgs, req, disableSigVerification)
+		if err != nil {
+			return err
+		}
+		return ctx.JSON(res)
+	}
+}
+
+// SubmitTransactionBatch validates a batch of transactions as a whole, and adds them to the pool if they are all
+// valid. Errors are returned as *fiber.Error, whose code is the HTTP status of the error.
+func SubmitTransactionBatch(
+	world servertypes.ProviderWorld, msgs map[string]map[string]types.Message, req *PostBatchTransactionRequest,
+	disableSigVerification bool,
+) (*PostBatchTransactionResponse, error) {
+	if len(req.Transactions) == 0 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid batch: "+ErrEmptyBatch.Error())
+	}
+
+	// Validate and decode every transaction before anything is submitted.
+	first := req.Transactions[0].Tx
+	txs := make([]txpool.TxData, 0, len(req.Transactions))
+	signerAddress := ""
+	for i := range req.Transactions {
+		batchTx := &req.Transactions[i]
+		tx := &batchTx.Tx
+		msgType, ok := lookupMessage(msgs, batchTx.Group, batchTx.Name, batchTx.Version)
+		if !ok {
+			return nil, fiber.NewError(fiber.StatusNotFound,
+				fmt.Sprintf("transaction %d: message type %s.%s not found", i, batchTx.Group, batchTx.Name))
+		}
+		if err := validateTx(tx); err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("transaction %d: invalid transaction payload: %v", i, err))
+		}
+		if tx.PersonaTag != first.PersonaTag {
+			return nil, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("transaction %d: %v", i, ErrBatchPersonaTagMismatch))
+		}
+		if tx.TargetTick != first.TargetTick {
+			return nil, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("transaction %d: %v", i, ErrBatchTargetTickMismatch))
+		}
+		if tx.ExpiryTick != first.ExpiryTick {
+			return nil, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("transaction %d: %v", i, ErrBatchExpiryTickMismatch))
+		}
+		if msgType.IsSystemOnly() && !tx.IsSystemTransaction() {
+			return nil, fiber.NewError(fiber.StatusForbidden,
+				fmt.Sprintf("transaction %d: %v", i, ErrSystemTransactionRequired))
+		}
+		msg, err := msgType.Decode(tx.Body)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest,
+				fmt.Sprintf("transaction %d: failed to decode message from transaction", i))
+		}
+		if !disableSigVerification {
+			txSigner, err := messageSignerAddress(world, msgType, msg)
+			if err != nil {
+				return nil, err
+			}
+			if txSigner == "" {
+				txSigner, err = world.GetSignerForPersonaTag(tx.PersonaTag, 0)
+				if err != nil {
+					return nil, fiber.NewError(fiber.StatusBadRequest,
+						fmt.Sprintf("transaction %d: could not get signer for persona: %v", i, err))
+				}
+			}
+			if signerAddress == "" {
+				signerAddress = txSigner
+			} else if signerAddress != txSigner {
+				return nil, fiber.NewError(fiber.StatusBadRequest,
+					fmt.Sprintf("transaction %d: %v", i, ErrBatchSignerMismatch))
+			}
+			if err = validateSignature(tx, signerAddress, world.Namespace(), tx.IsSystemTransaction()); err != nil {
+				return nil, fiber.NewError(fiber.StatusBadRequest,
+					fmt.Sprintf("transaction %d: failed to validate transaction: %v", i, err))
+			}
+		}
+		// The message is only validated once the signature of its transaction has been verified.
+		if err = world.ValidateTransaction(msgType.ID(), msg, tx); err != nil {
+			return nil, fiber.NewError(validationErrorStatus(err),
+				fmt.Sprintf("transaction %d: message validation failed: %v", i, err))
+		}
+
+		txs = append(txs, txpool.TxData{
+			MsgID:      msgType.ID(),
+			Msg:        msg,
+			Tx:         tx,
+			MsgVersion: msgType.Version(),
+		})
+	}
+
+	if !disableSigVerification {
+		nonces := make([]uint64, 0, len(txs))
+		for _, tx := range txs {
+			nonces = append(nonces, tx.Tx.Nonce)
+		}
+		if err := world.UseNonces(signerAddress, nonces); err != nil {
+			return nil, fiber.NewError(fiber.StatusInternalServerError, "failed to use nonces: "+err.Error())
+		}
+	}
+
+	tick, hashes := world.AddTransactions(txs)
+	res := &PostBatchTransactionResponse{
+		TxHashes: make([]string, 0, len(hashes)),
+		Tick:     tick,
+	}
+	for _, hash := range hashes {
+		res.TxHashes = append(res.TxHashes, string(hash))
+	}
+	return res, nil
+}
+
+// messageSignerAddress returns the address that must sign a transaction containing the given message. An empty address
+// is returned if the transaction must be signed by the signer of its persona.
+func messageSignerAddress(world servertypes.ProviderWorld, msgType types.Message, msg any) (string, error) {
+	// TODO(scott): don't hardcode this
+	if msgType.Name() == "create-persona" {
+		// don't need to check the cast bc we already validated this above
+		createPersonaMsg, _ := msg.(personaMsg.CreatePersona)
+		return createPersonaMsg.SignerAddress, nil
+	} else if msgType.IsSystemOnly() {
+		return systemSignerAddress(world)
+	}
+	return "", nil
+}
+
+// lookupSignerAndVerifySignature verifies the signature of the transaction, and returns the address that signed it. If
+// signerAddress is empty, the transaction must be signed by the signer of its persona.
+func lookupSignerAndVerifySignature(
+	world servertypes.ProviderWorld, signerAddress string, tx *Transaction,
+) (string, error) {
 	var err error
 	if signerAddress == "" {
 		signerAddress, err = world.GetSignerForPersonaTag(tx.PersonaTag, 0)
 		if err != nil {
-			return fiber.NewError(fiber.StatusBadRequest, "could not get signer for persona: "+err.Error())
+			return "", fiber.NewError(fiber.StatusBadRequest, "could not get signer for persona: "+err.Error())
 		}
 	}
 	if err = validateSignature(tx, signerAddress, world.Namespace(),
 		tx.IsSystemTransaction()); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, "failed to validate transaction: "+err.Error())
+		return "", fiber.NewError(fiber.StatusBadRequest, "failed to validate transaction: "+err.Error())
 	}
-	// TODO(scott): this should be refactored; it should be the responsibility of the engine tx processor
-	//  to mark the nonce as used once it's included in the tick, not the server.
-	if err = world.UseNonce(signerAddress, tx.Nonce); err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, "failed to use nonce: "+err.Error())
+	return signerAddress, nil
+}
+
+// systemSignerAddress returns the address that must sign the transactions of system-only messages.
+func systemSignerAddress(world servertypes.ProviderWorld) (string, error) {
+	signerAddress := world.SystemSignerAddress()
+	if signerAddress == "" {
+		return "", fiber.NewError(fiber.StatusForbidden, "failed to validate transaction: "+ErrSystemSignerNotSet.Error())
 	}
-	return nil
+	return signerAddress, nil
 }
 
-// validateTx validates the transaction payload
+// validationErrorStatus returns the status code for a transaction rejected by ProviderWorld.ValidateTransaction.
+func validationErrorStatus(err error) int {
+	if eris.Is(err, persona.ErrPersonaNotAuthorized) {
+		return fiber.StatusForbidden
+	}
+	return fiber.StatusBadRequest
+}
+
+// validateTx validates the transaction payload. Whether the transaction has already expired depends on the tick of the
+// transaction pool, so it is checked by ProviderWorld.ValidateTransaction instead.
 func validateTx(tx *Transaction) error {
 	// TODO(scott): we should use the validator package here
 	if tx.PersonaTag == "" {
 		return ErrNoPersonaTag
 	}
+	if tx.ExpiryTick != 0 && tx.ExpiryTick < tx.TargetTick {
+		return ErrExpiryBeforeTargetTick
+	}
 	return nil
 }
 
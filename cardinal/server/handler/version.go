@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+// versionParam returns the version in the version path parameter, which has the form v<version>. 0 is returned if the
+// route has no version, and false is returned if the version is malformed.
+func versionParam(ctx *fiber.Ctx) (int, bool) {
+	param := ctx.Params("version")
+	if param == "" {
+		return 0, true
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(param, "v"))
+	if err != nil || !strings.HasPrefix(param, "v") || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// lookupMessage returns the given version of a message. The latest version is returned if version is 0.
+func lookupMessage(msgs map[string]map[string]types.Message, group, name string, version int) (types.Message, bool) {
+	msgType, ok := msgs[group][name]
+	if !ok || version == 0 || version == msgType.Version() {
+		return msgType, ok
+	}
+	for _, previous := range msgType.PreviousVersions() {
+		if previous.Version() == version {
+			return previous, true
+		}
+	}
+	return nil, false
+}
@@ -17,12 +17,13 @@ type GetWorldResponse struct {
 	Components []types.FieldDetail `json:"components"` // list of component names
 	Messages   []types.FieldDetail `json:"messages"`
 	Queries    []types.FieldDetail `json:"queries"`
+	Events     []types.EventDetail `json:"events"` // events that systems emit with EmitTypedEvent
 }
 
 // GetWorld godoc
 //
 //	@Summary      Retrieves details of the game world
-//	@Description  Contains the registered components, messages, queries, and namespace
+//	@Description  Contains the registered components, messages, queries, events, and namespace
 //	@Accept       application/json
 //	@Produce      application/json
 //	@Success      200  {object}  GetWorldResponse  "Details of the game world"
@@ -34,6 +35,21 @@ func GetWorld(
 	messages []types.Message,
 	namespace string,
 ) func(*fiber.Ctx) error {
+	details := NewWorldDetails(components, messages)
+	return func(ctx *fiber.Ctx) error {
+		return ctx.JSON(details.Response(world, namespace))
+	}
+}
+
+// WorldDetails holds the details of the registered components and messages, which don't change once the world has
+// started. The queries and events are left to the world.
+type WorldDetails struct {
+	components []types.FieldDetail
+	messages   []types.FieldDetail
+}
+
+// NewWorldDetails collects the details of the given components and messages.
+func NewWorldDetails(components []types.ComponentMetadata, messages []types.Message) WorldDetails {
 	// Collecting name of all registered components
 	comps := make([]types.FieldDetail, 0, len(components))
 	for _, component := range components {
@@ -44,25 +60,41 @@ func GetWorld(
 		})
 	}
 
-	// Collecting the structure of all messages
+	// Collecting the structure of all messages, including their previous versions
 	messagesFields := make([]types.FieldDetail, 0, len(messages))
 	for _, message := range messages {
 		// Extracting the fields of the message
 		messagesFields = append(messagesFields, types.FieldDetail{
-			Name:   message.Name(),
-			Fields: message.GetInFieldInformation(),
-			URL:    utils.GetTxURL(message.Group(), message.Name()),
+			Name:       message.Name(),
+			Fields:     message.GetInFieldInformation(),
+			URL:        utils.GetTxURL(message.Group(), message.Name()),
+			Version:    message.Version(),
+			SystemOnly: message.IsSystemOnly(),
 		})
+		for _, previous := range message.PreviousVersions() {
+			messagesFields = append(messagesFields, types.FieldDetail{
+				Name:       previous.Name(),
+				Fields:     previous.GetInFieldInformation(),
+				URL:        utils.GetVersionedTxURL(previous.Group(), previous.Version(), previous.Name()),
+				Version:    previous.Version(),
+				SystemOnly: previous.IsSystemOnly(),
+			})
+		}
 	}
 
-	// Collecting the structure of all queries
+	return WorldDetails{
+		components: comps,
+		messages:   messagesFields,
+	}
+}
 
-	return func(ctx *fiber.Ctx) error {
-		return ctx.JSON(GetWorldResponse{
-			Namespace:  namespace,
-			Components: comps,
-			Messages:   messagesFields,
-			Queries:    world.BuildQueryFields(),
-		})
+// Response returns the details of the world, including its queries and events.
+func (d WorldDetails) Response(world servertypes.ProviderWorld, namespace string) GetWorldResponse {
+	return GetWorldResponse{
+		Namespace:  namespace,
+		Components: d.components,
+		Messages:   d.messages,
+		Queries:    world.BuildQueryFields(),
+		Events:     world.BuildEventFields(),
 	}
 }
@@ -0,0 +1,47 @@
+package server_test
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal"
+)
+
+func (s *ServerTestSuite) TestMetricsAreLabeledByNamespace() {
+	s.setupWorld(cardinal.WithMetrics())
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	moveMessage, ok := s.world.GetMessageByFullName("game." + moveMsgName)
+	s.Require().True(ok)
+	s.runTx(personaTag, moveMessage, MoveMsgInput{Direction: "up"})
+
+	res := s.fixture.Get("/metrics")
+	defer res.Body.Close()
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+	metrics := s.readBody(res.Body)
+
+	namespace := s.world.Namespace()
+	for _, want := range []string{
+		fmt.Sprintf(`cardinal_tick_duration_seconds_count{namespace=%q} 3`, namespace),
+		fmt.Sprintf(`cardinal_tick_transactions{namespace=%q} 1`, namespace),
+		fmt.Sprintf(`cardinal_transactions_total{message="game.move",namespace=%q} 1`, namespace),
+		fmt.Sprintf(`cardinal_transactions_total{message="persona.create-persona",namespace=%q} 1`, namespace),
+		fmt.Sprintf(`cardinal_system_duration_seconds_count{namespace=%q,system="cardinal.createPersonaSystem"} 3`,
+			namespace),
+		fmt.Sprintf(`cardinal_websocket_connections{endpoint="/events",namespace=%q} 0`, namespace),
+		fmt.Sprintf(`cardinal_redis_duration_seconds_count{command="pipeline",namespace=%q}`, namespace),
+		fmt.Sprintf(`go_goroutines{namespace=%q}`, namespace),
+	} {
+		s.Require().Contains(metrics, want)
+	}
+}
+
+func (s *ServerTestSuite) TestMetricsAreNotServedByDefault() {
+	s.setupWorld()
+	s.fixture.DoTick()
+
+	res := s.fixture.Get("/metrics")
+	defer res.Body.Close()
+	s.Require().Equal(fiber.StatusNotFound, res.StatusCode)
+}
@@ -1,5 +1,7 @@
 package server
 
+import "pkg.world.dev/world-engine/cardinal/telemetry"
+
 type Option func(s *Server)
 
 // WithPort allows the server to run on a specified port.
@@ -9,6 +11,13 @@ func WithPort(port string) Option {
 	}
 }
 
+// WithGRPCPort enables the gRPC server on the specified port. The gRPC server is disabled by default.
+func WithGRPCPort(port string) Option {
+	return func(s *Server) {
+		s.config.grpcPort = port
+	}
+}
+
 // DisableSignatureVerification disables signature verification.
 func DisableSignatureVerification() Option {
 	return func(s *Server) {
@@ -22,3 +31,43 @@ func DisableSwagger() Option {
 		s.config.isSwaggerDisabled = true
 	}
 }
+
+// WithDebugAPIKey requires requests to the debug routes, /debug/state and /cql, to hold the given API key as a bearer
+// token in the Authorization header.
+func WithDebugAPIKey(key string) Option {
+	return func(s *Server) {
+		s.config.debugAuth.APIKey = key
+	}
+}
+
+// WithDebugSignerAddress requires requests to the debug routes to be signed by the given address, unless they hold the
+// API key set with WithDebugAPIKey.
+func WithDebugSignerAddress(address string) Option {
+	return func(s *Server) {
+		s.config.debugAuth.SignerAddress = address
+	}
+}
+
+// DisableDebugRoutes removes the debug routes, which should not be reachable in production unless they are
+// authenticated.
+func DisableDebugRoutes() Option {
+	return func(s *Server) {
+		s.config.debugAuth.Disabled = true
+	}
+}
+
+// RequireDebugAuth disables the debug routes unless requests to them are authenticated with WithDebugAPIKey or
+// WithDebugSignerAddress, so the routes are closed by default instead of open to anyone.
+func RequireDebugAuth() Option {
+	return func(s *Server) {
+		s.config.isDebugAuthRequired = true
+	}
+}
+
+// WithMetrics serves the given metrics in the Prometheus format on /metrics, along with the number of open websocket
+// connections.
+func WithMetrics(metrics *telemetry.Metrics) Option {
+	return func(s *Server) {
+		s.config.metrics = metrics
+	}
+}
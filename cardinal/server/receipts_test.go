@@ -7,6 +7,7 @@ import (
 
 	"pkg.world.dev/world-engine/cardinal"
 	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/txpool"
 	"pkg.world.dev/world-engine/sign"
 )
 
@@ -49,18 +50,22 @@ func (s *ServerTestSuite) TestReceiptsQuery() {
 	s.Require().Equal(len(reply.Receipts), 2)
 
 	expectedReceipt1 := handler.ReceiptEntry{
-		TxHash: string(txHash1),
-		Tick:   0,
-		Result: fooOut{Y: 4},
-		Errors: nil,
+		TxHash:      string(txHash1),
+		Tick:        0,
+		PersonaTag:  "alpha",
+		MessageName: "game." + msgName,
+		Result:      fooOut{Y: 4},
+		Errors:      nil,
 	}
 	expectedJSON1, err := json.Marshal(expectedReceipt1)
 	s.Require().NoError(err)
 	expectedReceipt2 := handler.ReceiptEntry{
-		TxHash: string(txHash2),
-		Tick:   1,
-		Result: nil,
-		Errors: []string{wantErrorMessage},
+		TxHash:      string(txHash2),
+		Tick:        1,
+		PersonaTag:  "beta",
+		MessageName: "game." + msgName,
+		Result:      nil,
+		Errors:      []string{wantErrorMessage},
 	}
 	expectedJSON2, err := json.Marshal(expectedReceipt2)
 	s.Require().NoError(err)
@@ -77,3 +82,223 @@ func (s *ServerTestSuite) TestReceiptsQuery() {
 	s.Require().Equal(string(expectedJSON1), string(json1))
 	s.Require().Equal(string(expectedJSON2), string(json2))
 }
+
+func (s *ServerTestSuite) TestReceiptsQueryIncludesPendingTransactions() {
+	s.setupWorld()
+	world := s.world
+	type fooIn struct{}
+	type fooOut struct{ Y int }
+	msgName := "foo"
+	err := cardinal.RegisterMessage[fooIn, fooOut](world, msgName)
+	s.Require().NoError(err)
+	err = cardinal.RegisterSystems(world, func(ctx cardinal.WorldContext) error {
+		return cardinal.EachMessage[fooIn, fooOut](ctx, func(cardinal.TxData[fooIn]) (fooOut, error) {
+			return fooOut{Y: int(ctx.CurrentTick())}, nil
+		})
+	})
+	s.Require().NoError(err)
+	s.fixture.StartWorld()
+
+	fooMsg, ok := world.GetMessageByFullName("game." + msgName)
+	s.Require().True(ok)
+	targetTick := world.CurrentTick() + 2
+	tick, txHash := world.AddTransaction(fooMsg.ID(), fooIn{}, &sign.Transaction{
+		PersonaTag: "alpha",
+		TargetTick: targetTick,
+	})
+	s.Require().Equal(targetTick, tick)
+
+	listReceipts := func(req handler.ListTxReceiptsRequest) handler.ListTxReceiptsResponse {
+		res := s.fixture.Post("query/receipts/list", req)
+		s.Require().Equal(res.StatusCode, http.StatusOK)
+		var reply handler.ListTxReceiptsResponse
+		s.Require().NoError(json.NewDecoder(res.Body).Decode(&reply))
+		return reply
+	}
+
+	// The transaction is pending until the target tick is processed.
+	for world.CurrentTick() < targetTick {
+		reply := listReceipts(handler.ListTxReceiptsRequest{})
+		s.Require().Len(reply.Receipts, 0)
+		s.Require().Equal([]handler.PendingEntry{{TxHash: string(txHash), TargetTick: targetTick}}, reply.Pending)
+
+		// The filters of the request apply to the pending transactions.
+		reply = listReceipts(handler.ListTxReceiptsRequest{PersonaTag: "alpha", MessageName: fooMsg.FullName()})
+		s.Require().Len(reply.Pending, 1)
+		reply = listReceipts(handler.ListTxReceiptsRequest{PersonaTag: "beta"})
+		s.Require().Len(reply.Pending, 0)
+		reply = listReceipts(handler.ListTxReceiptsRequest{MessageName: "game.bar"})
+		s.Require().Len(reply.Pending, 0)
+
+		// The receipt of a pending transaction is its target tick.
+		res := s.fixture.Get("receipt/" + string(txHash))
+		s.Require().Equal(http.StatusAccepted, res.StatusCode)
+		var pending handler.PendingEntry
+		s.Require().NoError(json.NewDecoder(res.Body).Decode(&pending))
+		s.Require().Equal(handler.PendingEntry{TxHash: string(txHash), TargetTick: targetTick}, pending)
+		s.fixture.DoTick()
+	}
+	s.fixture.DoTick()
+
+	res := s.fixture.Get("receipt/" + string(txHash))
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+
+	reply := listReceipts(handler.ListTxReceiptsRequest{})
+	s.Require().Len(reply.Pending, 0)
+	s.Require().Len(reply.Receipts, 1)
+	s.Require().Equal(string(txHash), reply.Receipts[0].TxHash)
+	s.Require().Equal(targetTick, reply.Receipts[0].Tick)
+}
+
+func (s *ServerTestSuite) TestReceiptsQueryIsLimitedToMaxTicks() {
+	s.setupWorld()
+	for s.world.CurrentTick() < handler.MaxReceiptListTicks+5 {
+		s.fixture.DoTick()
+	}
+
+	res := s.fixture.Post("query/receipts/list", handler.ListTxReceiptsRequest{})
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	var reply handler.ListTxReceiptsResponse
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&reply))
+	s.Require().Equal(uint64(0), reply.StartTick)
+	s.Require().Equal(uint64(handler.MaxReceiptListTicks), reply.EndTick)
+}
+
+func (s *ServerTestSuite) TestReceiptsQueryIsLimitedToMaxPendingTransactions() {
+	s.setupWorld()
+	world := s.world
+	type fooIn struct{}
+	type fooOut struct{}
+	err := cardinal.RegisterMessage[fooIn, fooOut](world, "foo")
+	s.Require().NoError(err)
+	s.fixture.StartWorld()
+
+	fooMsg, ok := world.GetMessageByFullName("game.foo")
+	s.Require().True(ok)
+	for i := 0; i < handler.MaxPendingListSize+5; i++ {
+		world.AddTransaction(fooMsg.ID(), fooIn{}, &sign.Transaction{
+			PersonaTag: "alpha",
+			Nonce:      uint64(i),
+			TargetTick: world.CurrentTick() + 10,
+		})
+	}
+
+	res := s.fixture.Post("query/receipts/list", handler.ListTxReceiptsRequest{})
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	var reply handler.ListTxReceiptsResponse
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&reply))
+	s.Require().Len(reply.Pending, handler.MaxPendingListSize)
+}
+
+func (s *ServerTestSuite) TestExpiredTransactionsHaveErrorReceipts() {
+	s.setupWorld()
+	world := s.world
+	type fooIn struct{}
+	type fooOut struct{}
+	err := cardinal.RegisterMessage[fooIn, fooOut](world, "foo")
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+	s.fixture.DoTick()
+
+	fooMsg, ok := world.GetMessageByFullName("game.foo")
+	s.Require().True(ok)
+	_, txHash := world.AddTransaction(fooMsg.ID(), fooIn{}, &sign.Transaction{
+		PersonaTag: "alpha",
+		ExpiryTick: world.CurrentTick() - 1,
+	})
+	s.fixture.DoTick()
+
+	res := s.fixture.Post("query/receipts/list", handler.ListTxReceiptsRequest{})
+	s.Require().Equal(res.StatusCode, http.StatusOK)
+	var reply handler.ListTxReceiptsResponse
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&reply))
+	s.Require().Len(reply.Receipts, 1)
+	s.Require().Equal(string(txHash), reply.Receipts[0].TxHash)
+	s.Require().Equal([]string{txpool.ErrTransactionExpired.Error()}, reply.Receipts[0].Errors)
+}
+
+func (s *ServerTestSuite) TestReceiptsArePersistedBeyondHistorySize() {
+	s.setupWorld(cardinal.WithReceiptHistorySize(1), cardinal.WithReceiptRetention(100))
+	world := s.world
+	type fooIn struct{}
+	type fooOut struct{ Y int }
+	err := cardinal.RegisterMessage[fooIn, fooOut](world, "foo")
+	s.Require().NoError(err)
+	err = cardinal.RegisterSystems(world, func(ctx cardinal.WorldContext) error {
+		return cardinal.EachMessage[fooIn, fooOut](ctx, func(cardinal.TxData[fooIn]) (fooOut, error) {
+			return fooOut{Y: 4}, nil
+		})
+	})
+	s.Require().NoError(err)
+
+	fooMsg, ok := world.GetMessageByFullName("game.foo")
+	s.Require().True(ok)
+	_, txHash1 := world.AddTransaction(fooMsg.ID(), fooIn{}, &sign.Transaction{PersonaTag: "alpha"})
+	s.fixture.DoTick()
+	_, txHash2 := world.AddTransaction(fooMsg.ID(), fooIn{}, &sign.Transaction{PersonaTag: "beta"})
+	s.fixture.DoTick()
+	// Advance past the in-memory receipt history so the receipts can only come from storage.
+	s.fixture.DoTick()
+	s.fixture.DoTick()
+
+	res := s.fixture.Get("receipt/" + string(txHash1))
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+	var entry handler.ReceiptEntry
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&entry))
+	s.Require().Equal(string(txHash1), entry.TxHash)
+	s.Require().Equal(uint64(0), entry.Tick)
+	s.Require().Equal("alpha", entry.PersonaTag)
+	s.Require().Equal("game.foo", entry.MessageName)
+	s.Require().Equal(map[string]any{"Y": float64(4)}, entry.Result)
+
+	res = s.fixture.Get("receipt/0xdoesnotexist")
+	s.Require().Equal(http.StatusNotFound, res.StatusCode)
+
+	listReceipts := func(req handler.ListTxReceiptsRequest) handler.ListTxReceiptsResponse {
+		res := s.fixture.Post("query/receipts/list", req)
+		s.Require().Equal(http.StatusOK, res.StatusCode)
+		var reply handler.ListTxReceiptsResponse
+		s.Require().NoError(json.NewDecoder(res.Body).Decode(&reply))
+		return reply
+	}
+	reply := listReceipts(handler.ListTxReceiptsRequest{})
+	s.Require().Equal(uint64(0), reply.StartTick)
+	s.Require().Len(reply.Receipts, 2)
+
+	reply = listReceipts(handler.ListTxReceiptsRequest{PersonaTag: "beta"})
+	s.Require().Len(reply.Receipts, 1)
+	s.Require().Equal(string(txHash2), reply.Receipts[0].TxHash)
+	s.Require().Equal(uint64(1), reply.Receipts[0].Tick)
+
+	reply = listReceipts(handler.ListTxReceiptsRequest{MessageName: "game.foo"})
+	s.Require().Len(reply.Receipts, 2)
+	reply = listReceipts(handler.ListTxReceiptsRequest{MessageName: "game.bar"})
+	s.Require().Len(reply.Receipts, 0)
+}
+
+func (s *ServerTestSuite) TestPersistedReceiptsAreRemovedAfterRetention() {
+	s.setupWorld(cardinal.WithReceiptHistorySize(1), cardinal.WithReceiptRetention(2))
+	world := s.world
+	type fooIn struct{}
+	type fooOut struct{}
+	err := cardinal.RegisterMessage[fooIn, fooOut](world, "foo")
+	s.Require().NoError(err)
+	err = cardinal.RegisterSystems(world, func(ctx cardinal.WorldContext) error {
+		return cardinal.EachMessage[fooIn, fooOut](ctx, func(cardinal.TxData[fooIn]) (fooOut, error) {
+			return fooOut{}, nil
+		})
+	})
+	s.Require().NoError(err)
+
+	fooMsg, ok := world.GetMessageByFullName("game.foo")
+	s.Require().True(ok)
+	_, txHash := world.AddTransaction(fooMsg.ID(), fooIn{}, &sign.Transaction{PersonaTag: "alpha"})
+	s.fixture.DoTick()
+	s.fixture.DoTick()
+	res := s.fixture.Get("receipt/" + string(txHash))
+	s.Require().Equal(http.StatusOK, res.StatusCode)
+
+	s.fixture.DoTick()
+	res = s.fixture.Get("receipt/" + string(txHash))
+	s.Require().Equal(http.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,51 @@
+package server_test
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/persona/msg"
+	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/sign"
+)
+
+type SpawnMonsterMsg struct {
+	Monster string
+}
+
+type SpawnMonsterResult struct{}
+
+func (s *ServerTestSuite) TestRolesAreRequiredToSendMessages() {
+	const adminTag = "gamemaster"
+	s.setupWorld(cardinal.WithAdminPersonas(adminTag))
+	err := cardinal.RegisterMessage[SpawnMonsterMsg, SpawnMonsterResult](s.world, "spawn-monster",
+		cardinal.WithMsgRequiredRoles[SpawnMonsterMsg, SpawnMonsterResult]("moderator"))
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+	s.createPersona(adminTag)
+	personaTag := s.CreateRandomPersona()
+
+	post := func(personaTag, url string, payload any) (int, string) {
+		tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload)
+		s.Require().NoError(err)
+		res := s.fixture.Post(url, tx)
+		return res.StatusCode, s.readBody(res.Body)
+	}
+
+	spawnURL := utils.GetTxURL("game", "spawn-monster")
+	status, body := post(personaTag, spawnURL, SpawnMonsterMsg{Monster: "dragon"})
+	s.Require().Equal(fiber.StatusForbidden, status)
+	s.Require().Contains(body, "not authorized")
+
+	// Only admins can grant roles.
+	grant := msg.GrantRole{PersonaTag: personaTag, Role: "moderator"}
+	status, _ = post(personaTag, utils.GetTxURL("persona", msg.GrantRoleMessageName), grant)
+	s.Require().Equal(fiber.StatusForbidden, status)
+
+	grantRoleMsg, ok := s.world.GetMessageByFullName("persona." + msg.GrantRoleMessageName)
+	s.Require().True(ok)
+	s.runTx(adminTag, grantRoleMsg, grant)
+
+	status, body = post(personaTag, spawnURL, SpawnMonsterMsg{Monster: "dragon"})
+	s.Require().Equal(fiber.StatusOK, status, body)
+}
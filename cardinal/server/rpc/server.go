@@ -0,0 +1,409 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"pkg.world.dev/world-engine/cardinal/receipt"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+	"pkg.world.dev/world-engine/cardinal/types"
+	cardinalv1 "pkg.world.dev/world-engine/rift/cardinal/v1"
+)
+
+// tickResultsQueueSize is the number of tick results that can be waiting to be sent to a stream. Tick results are
+// dropped if the stream falls further behind.
+const tickResultsQueueSize = 100
+
+var _ cardinalv1.CardinalServer = (*Server)(nil)
+
+// Server is the gRPC counterpart of the REST API. It serves the same transactions, queries, world details and receipts,
+// and streams the results of each tick.
+type Server struct {
+	cardinalv1.UnimplementedCardinalServer
+
+	world                           servertypes.ProviderWorld
+	msgIndex                        map[string]map[string]types.Message
+	worldDetails                    handler.WorldDetails
+	isSignatureVerificationDisabled bool
+	debugAuth                       handler.DebugAuth
+	grpcServer                      *grpc.Server
+
+	mux     sync.RWMutex
+	streams map[chan *cardinalv1.StreamTickResultsResponse]struct{}
+	closed  chan struct{}
+}
+
+// New returns a gRPC server for the given world. msgIndex maps group -> name -> message, the same way it does for the
+// REST routes. EvaluateCQL is authenticated like the debug routes, except that it only accepts the API key.
+func New(
+	world servertypes.ProviderWorld,
+	components []types.ComponentMetadata,
+	messages []types.Message,
+	msgIndex map[string]map[string]types.Message,
+	disableSigVerification bool,
+	debugAuth handler.DebugAuth,
+) *Server {
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(requireRunningUnary(world)),
+		grpc.StreamInterceptor(requireRunningStream(world)),
+	)
+	s := &Server{
+		world:                           world,
+		msgIndex:                        msgIndex,
+		worldDetails:                    handler.NewWorldDetails(components, messages),
+		isSignatureVerificationDisabled: disableSigVerification,
+		debugAuth:                       debugAuth,
+		grpcServer:                      grpcServer,
+		streams:                         map[chan *cardinalv1.StreamTickResultsResponse]struct{}{},
+		closed:                          make(chan struct{}),
+	}
+	cardinalv1.RegisterCardinalServer(s.grpcServer, s)
+	return s
+}
+
+// requireRunningUnary rejects requests until the world is running, like the REST routes are while the world recovers
+// from the base shard.
+func requireRunningUnary(world servertypes.ProviderWorld) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, next grpc.UnaryHandler) (any, error) {
+		if !world.IsGameRunning() {
+			return nil, status.Error(codes.Unavailable, handler.ErrWorldNotRunning.Error())
+		}
+		return next(ctx, req)
+	}
+}
+
+// requireRunningStream rejects streams until the world is running.
+func requireRunningStream(world servertypes.ProviderWorld) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, next grpc.StreamHandler) error {
+		if !world.IsGameRunning() {
+			return status.Error(codes.Unavailable, handler.ErrWorldNotRunning.Error())
+		}
+		return next(srv, stream)
+	}
+}
+
+// Serve serves gRPC requests on the listener, blocking until the server is stopped.
+func (s *Server) Serve(listener net.Listener) error {
+	return eris.Wrap(s.grpcServer.Serve(listener), "error serving gRPC server")
+}
+
+// Stop ends all tick result streams and gracefully stops the server.
+func (s *Server) Stop() {
+	s.mux.Lock()
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+	}
+	s.mux.Unlock()
+	s.grpcServer.GracefulStop()
+}
+
+// BroadcastTickResults sends the results of a tick to every StreamTickResults stream. Streams that fall too far behind
+// miss the results.
+func (s *Server) BroadcastTickResults(results handler.TickResults) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	if len(s.streams) == 0 {
+		return
+	}
+
+	res := &cardinalv1.StreamTickResultsResponse{
+		Tick:     results.GetTick(),
+		Receipts: make([]*cardinalv1.Receipt, 0, len(results.GetReceipts())),
+		Events:   results.GetEvents(),
+	}
+	for _, r := range results.GetReceipts() {
+		rec, err := toReceipt(handler.NewReceiptEntry(r, results.GetTick()))
+		if err != nil {
+			log.Err(err).Msg("failed to convert receipt for tick results stream")
+			return
+		}
+		res.Receipts = append(res.Receipts, rec)
+	}
+	for stream := range s.streams {
+		select {
+		case stream <- res:
+		default:
+			log.Warn().Uint64("tick", res.GetTick()).Msg("tick results stream is too slow, dropping tick results")
+		}
+	}
+}
+
+func (s *Server) SendTransaction(
+	_ context.Context, req *cardinalv1.SendTransactionRequest,
+) (*cardinalv1.SendTransactionResponse, error) {
+	hash, tick, err := handler.SubmitTransaction(s.world, s.msgIndex, req.GetGroup(), req.GetName(),
+		int(req.GetVersion()), fromTransaction(req.GetTx()), s.isSignatureVerificationDisabled)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &cardinalv1.SendTransactionResponse{TxHash: string(hash), Tick: tick}, nil
+}
+
+func (s *Server) SendTransactionBatch(
+	_ context.Context, req *cardinalv1.SendTransactionBatchRequest,
+) (*cardinalv1.SendTransactionBatchResponse, error) {
+	batch := &handler.PostBatchTransactionRequest{
+		Transactions: make([]handler.BatchTransaction, 0, len(req.GetTransactions())),
+	}
+	for _, tx := range req.GetTransactions() {
+		batch.Transactions = append(batch.Transactions, handler.BatchTransaction{
+			Group:   tx.GetGroup(),
+			Name:    tx.GetName(),
+			Version: int(tx.GetVersion()),
+			Tx:      *fromTransaction(tx.GetTx()),
+		})
+	}
+	res, err := handler.SubmitTransactionBatch(s.world, s.msgIndex, batch, s.isSignatureVerificationDisabled)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &cardinalv1.SendTransactionBatchResponse{TxHashes: res.TxHashes, Tick: res.Tick}, nil
+}
+
+func (s *Server) Query(_ context.Context, req *cardinalv1.QueryRequest) (*cardinalv1.QueryResponse, error) {
+	var reply []byte
+	var err error
+	if req.GetVersion() == 0 {
+		reply, err = s.world.HandleQuery(req.GetGroup(), req.GetName(), req.GetRequest())
+	} else {
+		reply, err = s.world.HandleVersionedQuery(req.GetGroup(), req.GetName(), int(req.GetVersion()), req.GetRequest())
+	}
+	if eris.Is(err, types.ErrQueryNotFound) {
+		return nil, status.Error(codes.NotFound, "query not found")
+	} else if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "encountered an error in query: "+err.Error())
+	}
+	return &cardinalv1.QueryResponse{Reply: reply}, nil
+}
+
+func (s *Server) EvaluateCQL(
+	ctx context.Context, req *cardinalv1.EvaluateCQLRequest,
+) (*cardinalv1.EvaluateCQLResponse, error) {
+	if err := s.authenticateDebugRequest(ctx); err != nil {
+		return nil, err
+	}
+	results, err := s.world.EvaluateCQL(req.GetCql())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &cardinalv1.EvaluateCQLResponse{Results: make([]*cardinalv1.Entity, 0, len(results))}
+	for _, result := range results {
+		entity := &cardinalv1.Entity{Id: uint64(result.ID), Data: make([][]byte, 0, len(result.Data))}
+		for _, data := range result.Data {
+			entity.Data = append(entity.Data, data)
+		}
+		res.Results = append(res.Results, entity)
+	}
+	return res, nil
+}
+
+func (s *Server) GetWorld(context.Context, *cardinalv1.GetWorldRequest) (*cardinalv1.GetWorldResponse, error) {
+	details := s.worldDetails.Response(s.world, s.world.Namespace())
+	res := &cardinalv1.GetWorldResponse{Namespace: details.Namespace}
+	var err error
+	if res.Components, err = toFieldDetails(details.Components); err != nil {
+		return nil, err
+	}
+	if res.Messages, err = toFieldDetails(details.Messages); err != nil {
+		return nil, err
+	}
+	if res.Queries, err = toFieldDetails(details.Queries); err != nil {
+		return nil, err
+	}
+	for _, event := range details.Events {
+		fields, err := json.Marshal(event.Fields)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to marshal event fields: "+err.Error())
+		}
+		res.Events = append(res.Events, &cardinalv1.EventDetail{Name: event.Name, Fields: fields, Schema: event.Schema})
+	}
+	return res, nil
+}
+
+func (s *Server) GetReceipt(
+	_ context.Context, req *cardinalv1.GetReceiptRequest,
+) (*cardinalv1.GetReceiptResponse, error) {
+	txHash := types.TxHash(req.GetTxHash())
+	rec, tick, err := s.world.GetTransactionReceipt(txHash)
+	if errors.Is(err, receipt.ErrReceiptNotFound) {
+		if pending, ok := handler.FindPendingTransaction(s.world, txHash); ok {
+			return &cardinalv1.GetReceiptResponse{Pending: &cardinalv1.PendingTransaction{
+				TxHash:     pending.TxHash,
+				TargetTick: pending.TargetTick,
+			}}, nil
+		}
+		return nil, status.Error(codes.NotFound, "receipt not found")
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, "failed to get receipt: "+err.Error())
+	}
+	res, err := toReceipt(handler.NewReceiptEntry(rec, tick))
+	if err != nil {
+		return nil, err
+	}
+	return &cardinalv1.GetReceiptResponse{Receipt: res}, nil
+}
+
+func (s *Server) ListReceipts(
+	_ context.Context, req *cardinalv1.ListReceiptsRequest,
+) (*cardinalv1.ListReceiptsResponse, error) {
+	reply, err := handler.ListReceipts(s.world, &handler.ListTxReceiptsRequest{
+		StartTick:   req.GetStartTick(),
+		PersonaTag:  req.GetPersonaTag(),
+		MessageName: req.GetMessageName(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	res := &cardinalv1.ListReceiptsResponse{StartTick: reply.StartTick, EndTick: reply.EndTick}
+	for _, entry := range reply.Receipts {
+		rec, err := toReceipt(entry)
+		if err != nil {
+			return nil, err
+		}
+		res.Receipts = append(res.Receipts, rec)
+	}
+	for _, pending := range reply.Pending {
+		res.Pending = append(res.Pending, &cardinalv1.PendingTransaction{
+			TxHash:     pending.TxHash,
+			TargetTick: pending.TargetTick,
+		})
+	}
+	return res, nil
+}
+
+func (s *Server) StreamTickResults(
+	_ *cardinalv1.StreamTickResultsRequest, stream cardinalv1.Cardinal_StreamTickResultsServer,
+) error {
+	results := make(chan *cardinalv1.StreamTickResultsResponse, tickResultsQueueSize)
+	s.mux.Lock()
+	s.streams[results] = struct{}{}
+	s.mux.Unlock()
+	defer func() {
+		s.mux.Lock()
+		delete(s.streams, results)
+		s.mux.Unlock()
+	}()
+	// Headers are sent once the stream is registered, so clients know from when on they receive the tick results.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case res := <-results:
+			if err := stream.Send(res); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-s.closed:
+			return nil
+		}
+	}
+}
+
+// fromTransaction converts a transaction of a request to the transaction the handlers expect. A missing transaction
+// is converted to an empty one, which fails validation.
+func fromTransaction(tx *cardinalv1.Transaction) *handler.Transaction {
+	res := &handler.Transaction{
+		PersonaTag: tx.GetPersonaTag(),
+		Namespace:  tx.GetNamespace(),
+		Nonce:      tx.GetNonce(),
+		Signature:  tx.GetSignature(),
+		Body:       tx.GetBody(),
+		TargetTick: tx.GetTargetTick(),
+		ExpiryTick: tx.GetExpiryTick(),
+	}
+	if tx.GetHash() != "" {
+		res.Hash = common.HexToHash(tx.GetHash())
+	}
+	return res
+}
+
+func toReceipt(entry handler.ReceiptEntry) (*cardinalv1.Receipt, error) {
+	result, err := json.Marshal(entry.Result)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to marshal receipt result: "+err.Error())
+	}
+	return &cardinalv1.Receipt{
+		TxHash:      entry.TxHash,
+		Tick:        entry.Tick,
+		PersonaTag:  entry.PersonaTag,
+		MessageName: entry.MessageName,
+		Result:      result,
+		Errors:      entry.Errors,
+	}, nil
+}
+
+func toFieldDetails(details []types.FieldDetail) ([]*cardinalv1.FieldDetail, error) {
+	res := make([]*cardinalv1.FieldDetail, 0, len(details))
+	for _, detail := range details {
+		fields, err := json.Marshal(detail.Fields)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "failed to marshal fields of "+detail.Name+": "+err.Error())
+		}
+		res = append(res, &cardinalv1.FieldDetail{
+			Name:       detail.Name,
+			Fields:     fields,
+			Url:        detail.URL,
+			Version:    uint32(detail.Version),
+			SystemOnly: detail.SystemOnly,
+		})
+	}
+	return res, nil
+}
+
+// authenticateDebugRequest checks that a request to a debug RPC holds the API key in its authorization metadata. Signed
+// requests are only supported by the REST routes.
+func (s *Server) authenticateDebugRequest(ctx context.Context) error {
+	if s.debugAuth.Disabled {
+		return status.Error(codes.Unimplemented, "debug routes are disabled")
+	}
+	if s.debugAuth.IsOpen() {
+		return nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, authorization := range md.Get("authorization") {
+		if s.debugAuth.HasAPIKey(authorization) {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, handler.ErrUnauthenticated.Error())
+}
+
+// toStatusError converts the *fiber.Error returned by a handler to a gRPC status error with the matching code.
+func toStatusError(err error) error {
+	var fiberErr *fiber.Error
+	if !errors.As(err, &fiberErr) {
+		return status.Error(codes.Internal, err.Error())
+	}
+	code := codes.Unknown
+	switch fiberErr.Code {
+	case fiber.StatusBadRequest:
+		code = codes.InvalidArgument
+	case fiber.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case fiber.StatusForbidden:
+		code = codes.PermissionDenied
+	case fiber.StatusNotFound:
+		code = codes.NotFound
+	case fiber.StatusInternalServerError:
+		code = codes.Internal
+	}
+	return status.Error(code, fiberErr.Message)
+}
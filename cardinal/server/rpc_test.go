@@ -0,0 +1,148 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"pkg.world.dev/world-engine/cardinal"
+	cardinalv1 "pkg.world.dev/world-engine/rift/cardinal/v1"
+	"pkg.world.dev/world-engine/sign"
+)
+
+// newCardinalClient sets up a world with the gRPC server enabled and returns a client connected to it.
+func (s *ServerTestSuite) newCardinalClient(opts ...cardinal.WorldOption) cardinalv1.CardinalClient {
+	listener, err := net.Listen("tcp", "localhost:0")
+	s.Require().NoError(err)
+	port := strconv.Itoa(listener.Addr().(*net.TCPAddr).Port)
+	s.Require().NoError(listener.Close())
+
+	s.setupWorld(append(opts, cardinal.WithGRPCPort(port))...)
+	s.fixture.DoTick()
+
+	conn, err := grpc.NewClient("localhost:"+port, grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)))
+	s.Require().NoError(err)
+	s.T().Cleanup(func() {
+		_ = conn.Close()
+	})
+	return cardinalv1.NewCardinalClient(conn)
+}
+
+func (s *ServerTestSuite) TestCanSendTransactionsAndQueryOverGRPC() {
+	client := s.newCardinalClient()
+	ctx := context.Background()
+	personaTag := s.CreateRandomPersona()
+
+	world, err := client.GetWorld(ctx, &cardinalv1.GetWorldRequest{})
+	s.Require().NoError(err)
+	s.Require().Equal(s.world.Namespace(), world.GetNamespace())
+	s.Require().Len(world.GetComponents(), len(s.world.GetRegisteredComponents()))
+	s.Require().Len(world.GetMessages(), len(s.world.GetRegisteredMessages()))
+	s.Require().Len(world.GetQueries(), len(s.world.GetRegisteredQueries()))
+
+	// The stream only receives the results of the ticks that complete after its headers are received.
+	stream, err := client.StreamTickResults(ctx, &cardinalv1.StreamTickResultsRequest{})
+	s.Require().NoError(err)
+	_, err = stream.Header()
+	s.Require().NoError(err)
+
+	tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, MoveMsgInput{"up"})
+	s.Require().NoError(err)
+	s.nonce++
+	sent, err := client.SendTransaction(ctx, &cardinalv1.SendTransactionRequest{
+		Group: "game",
+		Name:  moveMsgName,
+		Tx: &cardinalv1.Transaction{
+			PersonaTag: tx.PersonaTag,
+			Namespace:  tx.Namespace,
+			Nonce:      tx.Nonce,
+			Signature:  tx.Signature,
+			Hash:       tx.Hash.Hex(),
+			Body:       tx.Body,
+		},
+	})
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+
+	results, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().Equal(sent.GetTick(), results.GetTick())
+	s.Require().Len(results.GetReceipts(), 1)
+	s.Require().Equal(sent.GetTxHash(), results.GetReceipts()[0].GetTxHash())
+
+	rec, err := client.GetReceipt(ctx, &cardinalv1.GetReceiptRequest{TxHash: sent.GetTxHash()})
+	s.Require().NoError(err)
+	s.Require().Equal(personaTag, rec.GetReceipt().GetPersonaTag())
+	s.Require().Empty(rec.GetReceipt().GetErrors())
+	var output MoveMessageOutput
+	s.Require().NoError(json.Unmarshal(rec.GetReceipt().GetResult(), &output))
+	s.Require().Equal(LocationComponent{0, 1}, output.Location)
+
+	receipts, err := client.ListReceipts(ctx, &cardinalv1.ListReceiptsRequest{PersonaTag: personaTag})
+	s.Require().NoError(err)
+	s.Require().Len(receipts.GetReceipts(), 1)
+	s.Require().Equal(sent.GetTxHash(), receipts.GetReceipts()[0].GetTxHash())
+
+	req, err := json.Marshal(QueryLocationRequest{Persona: personaTag})
+	s.Require().NoError(err)
+	reply, err := client.Query(ctx, &cardinalv1.QueryRequest{Group: "game", Name: "location", Request: req})
+	s.Require().NoError(err)
+	var loc QueryLocationResponse
+	s.Require().NoError(json.Unmarshal(reply.GetReply(), &loc))
+	s.Require().Equal(LocationComponent{0, 1}, loc.LocationComponent)
+
+	entities, err := client.EvaluateCQL(ctx, &cardinalv1.EvaluateCQLRequest{Cql: "CONTAINS(location)"})
+	s.Require().NoError(err)
+	s.Require().Len(entities.GetResults(), 1)
+}
+
+func (s *ServerTestSuite) TestGRPCErrorsHaveMatchingStatusCodes() {
+	client := s.newCardinalClient()
+	ctx := context.Background()
+
+	_, err := client.SendTransaction(ctx, &cardinalv1.SendTransactionRequest{Group: "game", Name: "does-not-exist"})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+
+	_, err = client.SendTransaction(ctx, &cardinalv1.SendTransactionRequest{Group: "game", Name: moveMsgName})
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Query(ctx, &cardinalv1.QueryRequest{Group: "game", Name: "does-not-exist"})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+
+	_, err = client.GetReceipt(ctx, &cardinalv1.GetReceiptRequest{TxHash: "0x1234"})
+	s.Require().Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestGRPCReceiptsOfPendingTransactionsHaveTheirTargetTick() {
+	client := s.newCardinalClient()
+	moveMsg, ok := s.world.GetMessageByFullName("game." + moveMsgName)
+	s.Require().True(ok)
+	targetTick := s.world.CurrentTick() + 2
+	_, txHash := s.world.AddTransaction(moveMsg.ID(), MoveMsgInput{"up"}, &sign.Transaction{TargetTick: targetTick})
+
+	rec, err := client.GetReceipt(context.Background(), &cardinalv1.GetReceiptRequest{TxHash: string(txHash)})
+	s.Require().NoError(err)
+	s.Require().Nil(rec.GetReceipt())
+	s.Require().Equal(string(txHash), rec.GetPending().GetTxHash())
+	s.Require().Equal(targetTick, rec.GetPending().GetTargetTick())
+}
+
+func (s *ServerTestSuite) TestGRPCEvaluateCQLRequiresDebugAPIKey() {
+	client := s.newCardinalClient(cardinal.WithDebugAPIKey("secret"))
+	req := &cardinalv1.EvaluateCQLRequest{Cql: "CONTAINS(location)"}
+
+	_, err := client.EvaluateCQL(context.Background(), req)
+	s.Require().Equal(codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer secret")
+	_, err = client.EvaluateCQL(ctx, req)
+	s.Require().NoError(err)
+}
@@ -2,18 +2,22 @@ package server
 
 import (
 	"context"
-	"encoding/json"
+	"net"
 	"time"
 
 	"github.com/gofiber/contrib/socketio"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/swagger"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rotisserie/eris"
 	"github.com/rs/zerolog/log"
 
 	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/server/rpc"
 	servertypes "pkg.world.dev/world-engine/cardinal/server/types"
+	"pkg.world.dev/world-engine/cardinal/telemetry"
 	"pkg.world.dev/world-engine/cardinal/types"
 
 	_ "pkg.world.dev/world-engine/cardinal/server/docs" // for swagger.
@@ -26,13 +30,24 @@ const (
 
 type config struct {
 	port                            string
+	grpcPort                        string
 	isSignatureVerificationDisabled bool
 	isSwaggerDisabled               bool
+	debugAuth                       handler.DebugAuth
+	isDebugAuthRequired             bool
+	// metrics are served on /metrics if they are set.
+	metrics *telemetry.Metrics
 }
 
 type Server struct {
 	app    *fiber.App
 	config config
+	// events are the websocket connections that receive the results of each tick.
+	events *handler.EventSubscribers
+	// privateEvents are the websocket connections that subscribed to the private events of a persona.
+	privateEvents *handler.PrivateEventSubscribers
+	// rpc is the gRPC server, which is only set if a gRPC port was given.
+	rpc *rpc.Server
 }
 
 // New returns an HTTP server with handlers for all QueryTypes and MessageTypes.
@@ -48,22 +63,46 @@ func New(
 	})
 
 	s := &Server{
-		app: app,
+		app:           app,
+		events:        handler.NewEventSubscribers(),
+		privateEvents: handler.NewPrivateEventSubscribers(),
 		config: config{
 			port:                            defaultPort,
+			grpcPort:                        "",
 			isSignatureVerificationDisabled: false,
 			isSwaggerDisabled:               false,
+			debugAuth:                       handler.DebugAuth{},
+			isDebugAuthRequired:             false,
+			metrics:                         nil,
 		},
 	}
 	for _, opt := range opts {
 		opt(s)
 	}
+	if s.config.isDebugAuthRequired && s.config.debugAuth.IsOpen() {
+		s.config.debugAuth.Disabled = true
+	}
 
 	// Enable CORS
 	app.Use(cors.New())
 
+	if s.config.metrics != nil {
+		if err := s.config.metrics.RegisterWebsocketConnections("/events", s.events.Count); err != nil {
+			return nil, err
+		}
+		if err := s.config.metrics.RegisterWebsocketConnections("/events/private", s.privateEvents.Count); err != nil {
+			return nil, err
+		}
+	}
+
 	// Register routes
-	s.setupRoutes(world, messages, components)
+	msgIndex := newMessageIndex(messages)
+	s.setupRoutes(world, msgIndex, messages, components)
+
+	if s.config.grpcPort != "" {
+		s.rpc = rpc.New(world, components, messages, msgIndex, s.config.isSignatureVerificationDisabled,
+			s.config.debugAuth)
+	}
 
 	return s, nil
 }
@@ -71,7 +110,7 @@ func New(
 // Serve serves the application, blocking the calling thread.
 // Call this in a new go routine to prevent blocking.
 func (s *Server) Serve(ctx context.Context) error {
-	serverErr := make(chan error, 1)
+	serverErr := make(chan error, 2)
 
 	// Starts the server in a new goroutine
 	go func() {
@@ -81,6 +120,20 @@ func (s *Server) Serve(ctx context.Context) error {
 		}
 	}()
 
+	if s.rpc != nil {
+		go func() {
+			log.Info().Msgf("Starting gRPC server at port %s", s.config.grpcPort)
+			listener, err := net.Listen("tcp", ":"+s.config.grpcPort)
+			if err != nil {
+				serverErr <- eris.Wrap(err, "error starting gRPC server")
+				return
+			}
+			if err = s.rpc.Serve(listener); err != nil {
+				serverErr <- err
+			}
+		}()
+	}
+
 	// This function will block until the server is shutdown or the context is canceled.
 	select {
 	case err := <-serverErr:
@@ -94,13 +147,25 @@ func (s *Server) Serve(ctx context.Context) error {
 	return nil
 }
 
+// BroadcastEvent sends the given event to the websocket connections on /events. Connections that subscribed to topics
+// only receive the parts of the event that match their topics. The results of a tick are also sent to the gRPC
+// StreamTickResults streams.
 func (s *Server) BroadcastEvent(event any) error {
-	eventBz, err := json.Marshal(event)
-	if err != nil {
-		return err
+	if results, ok := event.(handler.TickResults); ok && s.rpc != nil {
+		s.rpc.BroadcastTickResults(results)
 	}
-	socketio.Broadcast(eventBz)
-	return nil
+	return s.events.Broadcast(event)
+}
+
+// SubscribedEntities returns the IDs of the entities whose state changes websocket connections subscribed to.
+func (s *Server) SubscribedEntities() []types.EntityID {
+	return s.events.SubscribedEntities()
+}
+
+// SendPrivateEvents sends the events of a tick that were emitted to the given persona to the websocket connections that
+// subscribed to its private events.
+func (s *Server) SendPrivateEvents(personaTag string, tick uint64, events [][]byte) {
+	s.privateEvents.Send(personaTag, tick, events)
 }
 
 // Shutdown gracefully shuts down the server and closes all active websocket connections.
@@ -110,6 +175,11 @@ func (s *Server) shutdown() error {
 	// Close websocket connections
 	socketio.Broadcast([]byte(""), socketio.CloseMessage)
 	socketio.Fire(socketio.EventClose, nil)
+	s.privateEvents.Close()
+
+	if s.rpc != nil {
+		s.rpc.Stop()
+	}
 
 	// Gracefully shutdown Fiber server
 	if err := s.app.ShutdownWithTimeout(shutdownTimeout); err != nil {
@@ -129,49 +199,74 @@ func (s *Server) shutdown() error {
 // @produces		application/json
 func (s *Server) setupRoutes(
 	world servertypes.ProviderWorld,
+	msgIndex map[string]map[string]types.Message,
 	messages []types.Message,
 	components []types.ComponentMetadata,
 ) {
-	// /tx/:group/:txType
-	// maps group -> txType -> tx
-	msgIndex := make(map[string]map[string]types.Message)
-
-	// Create tx index
-	for _, msg := range messages {
-		// Initialize inner map if it doesn't exist
-		if _, ok := msgIndex[msg.Group()]; !ok {
-			msgIndex[msg.Group()] = make(map[string]types.Message)
-		}
-		msgIndex[msg.Group()][msg.Name()] = msg
-	}
-
 	// Route: /swagger/
 	if !s.config.isSwaggerDisabled {
 		s.app.Get("/swagger/*", swagger.HandlerDefault)
 	}
 
-	// Route: /events/
-	s.app.Use("/events", handler.WebSocketUpgrader)
-	s.app.Get("/events", handler.WebSocketEvents())
-
 	// Route: /world
 	s.app.Get("/world", handler.GetWorld(world, components, messages, world.Namespace()))
 
+	// Route: /openapi.json
+	s.app.Get("/openapi.json", handler.GetOpenAPI(world, components, messages))
+
 	// Route: /...
-	s.app.Get("/health", handler.GetHealth())
+	s.app.Get("/health", handler.GetHealth(world))
+	s.app.Get("/ready", handler.GetReady(world))
+	if s.config.metrics != nil {
+		s.app.Get("/metrics", adaptor.HTTPHandler(promhttp.HandlerFor(s.config.metrics.Gatherer(), promhttp.HandlerOpts{})))
+	}
+
+	// The server starts before the world recovers from the base shard, so the routes below are rejected until the
+	// world is running.
+	s.app.Use(handler.RequireRunning(world))
+
+	// Route: /events/
+	// The history is served over HTTP, so it must be registered before the websocket upgrader.
+	s.app.Get("/events/history", handler.GetEventHistory(world))
+	s.app.Use("/events", handler.WebSocketUpgrader)
+	s.app.Get("/events", handler.WebSocketEvents(world, s.events))
+	s.app.Get("/events/private", handler.WebSocketPrivateEvents(world, s.privateEvents))
 
 	// Route: /query/...
 	query := s.app.Group("/query")
 	query.Post("/receipts/list", handler.GetReceipts(world))
 	query.Post("/:group/:name", handler.PostQuery(world))
+	query.Post("/:group/:version/:name", handler.PostVersionedQuery(world))
+
+	// Route: /receipt/...
+	s.app.Get("/receipt/:txHash", handler.GetReceipt(world))
 
 	// Route: /tx/...
 	tx := s.app.Group("/tx")
+	tx.Post("/batch", handler.PostBatchTransaction(world, msgIndex, s.config.isSignatureVerificationDisabled))
+	tx.Post("/simulate/:group/:name",
+		handler.PostSimulateTransaction(world, msgIndex, s.config.isSignatureVerificationDisabled))
 	tx.Post("/:group/:name", handler.PostTransaction(world, msgIndex, s.config.isSignatureVerificationDisabled))
+	tx.Post("/:group/:version/:name",
+		handler.PostVersionedTransaction(world, msgIndex, s.config.isSignatureVerificationDisabled))
 
-	// Route: /cql
-	s.app.Post("/cql", handler.PostCQL(world))
+	// Route: /cql, /debug/state
+	if !s.config.debugAuth.Disabled {
+		auth := handler.AuthenticateDebugRequest(world, s.config.debugAuth)
+		s.app.Post("/cql", auth, handler.PostCQL(world))
+		s.app.Post("/debug/state", auth, handler.GetState(world))
+	}
+}
 
-	// Route: /debug/state
-	s.app.Post("/debug/state", handler.GetState(world))
+// newMessageIndex maps group -> name -> message, which is how the /tx routes and the gRPC server look up messages.
+func newMessageIndex(messages []types.Message) map[string]map[string]types.Message {
+	msgIndex := make(map[string]map[string]types.Message)
+	for _, msg := range messages {
+		// Initialize inner map if it doesn't exist
+		if _, ok := msgIndex[msg.Group()]; !ok {
+			msgIndex[msg.Group()] = make(map[string]types.Message)
+		}
+		msgIndex[msg.Group()][msg.Name()] = msg
+	}
+	return msgIndex
 }
@@ -22,6 +22,7 @@ import (
 	"pkg.world.dev/world-engine/cardinal/persona/msg"
 	"pkg.world.dev/world-engine/cardinal/server/handler"
 	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/cardinal/txpool"
 	"pkg.world.dev/world-engine/cardinal/types"
 	"pkg.world.dev/world-engine/sign"
 )
@@ -124,6 +125,62 @@ func (s *ServerTestSuite) TestGetWorld() {
 	assert.Equal(s.T(), s.world.Namespace(), result.Namespace)
 }
 
+func (s *ServerTestSuite) TestOpenAPIDocumentDescribesRegisteredMessagesAndQueries() {
+	s.setupWorld()
+	s.fixture.DoTick()
+	res := s.fixture.Get("/openapi.json")
+	defer res.Body.Close()
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+	var doc handler.OpenAPIDocument
+	s.Require().NoError(json.NewDecoder(res.Body).Decode(&doc))
+	s.Require().Equal(s.world.Namespace(), doc.Info.Title)
+
+	for _, msg := range s.world.GetRegisteredMessages() {
+		s.Require().Contains(doc.Paths, utils.GetTxURL(msg.Group(), msg.Name()))
+	}
+	for _, query := range s.world.GetRegisteredQueries() {
+		s.Require().Contains(doc.Paths, utils.GetQueryURL(query.Group(), query.Name()))
+	}
+	for _, comp := range s.world.GetRegisteredComponents() {
+		s.Require().Contains(doc.Components["schemas"], comp.Name())
+	}
+
+	// The body of a message is described by the fields of its input type.
+	bz, err := json.Marshal(doc.Paths[utils.GetTxURL("game", moveMsgName)])
+	s.Require().NoError(err)
+	s.Require().Contains(string(bz), `"body":{"properties":{"Direction":{"type":"string"}},"type":"object"}`)
+	bz, err = json.Marshal(doc.Paths[utils.GetQueryURL("game", "location")])
+	s.Require().NoError(err)
+	s.Require().Contains(string(bz), `"schema":{"properties":{"Persona":{"type":"string"}},"type":"object"}`)
+
+	// The references of component schemas point into the document.
+	bz, err = json.Marshal(doc.Components["schemas"][LocationComponent{}.Name()])
+	s.Require().NoError(err)
+	s.Require().Contains(string(bz), `"$ref":"#/components/schemas/location/$defs/LocationComponent"`)
+}
+
+func (s *ServerTestSuite) TestHealthAndReadyReportTheLastTick() {
+	s.setupWorld()
+	s.fixture.DoTick()
+	s.fixture.DoTick()
+
+	for _, path := range []string{"health", "ready"} {
+		res := s.fixture.Get(path)
+		s.Require().Equal(fiber.StatusOK, res.StatusCode)
+		var health handler.GetHealthResponse
+		s.Require().NoError(json.Unmarshal([]byte(s.readBody(res.Body)), &health))
+		s.Require().True(health.IsReady)
+		s.Require().Equal("Running", health.Stage)
+		s.Require().True(health.Redis.Connected)
+		s.Require().NotNil(health.LastTick)
+		s.Require().Equal(uint64(1), health.LastTick.Tick)
+		s.Require().GreaterOrEqual(health.LastTick.AgeMs, int64(0))
+		// The world is not in rollup mode, so it has no router and did not recover from the base shard.
+		s.Require().Nil(health.Router)
+		s.Require().Nil(health.Recovery)
+	}
+}
+
 // TestSwaggerEndpointsAreActuallyCreated verifies the non-variable endpoints that are declared in the swagger.yml file
 // actually have endpoints when the cardinal server starts.
 func (s *ServerTestSuite) TestSwaggerEndpointsAreActuallyCreated() {
@@ -244,6 +301,137 @@ func (s *ServerTestSuite) TestSignerAddressIsRequiredWhenSigVerificationIsDisabl
 	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
 }
 
+func (s *ServerTestSuite) TestExpiredTransactionsAreRejected() {
+	s.setupWorld()
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	moveMessage, ok := s.world.GetMessageByFullName("game." + moveMsgName)
+	s.Require().True(ok)
+	url := utils.GetTxURL(moveMessage.Group(), moveMessage.Name())
+	currentTick := s.world.CurrentTick()
+	payload := MoveMsgInput{Direction: "up"}
+
+	// Already expired.
+	tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload,
+		sign.WithExpiryTick(currentTick-1))
+	s.Require().NoError(err)
+	res := s.fixture.Post(url, tx)
+	s.Require().Equal(http.StatusBadRequest, res.StatusCode)
+
+	// Expires before it would be executed.
+	tx, err = sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload,
+		sign.WithTargetTick(currentTick+5), sign.WithExpiryTick(currentTick+4))
+	s.Require().NoError(err)
+	res = s.fixture.Post(url, tx)
+	s.Require().Equal(http.StatusBadRequest, res.StatusCode)
+
+	// Expires in the tick it is executed in.
+	tx, err = sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload,
+		sign.WithExpiryTick(currentTick))
+	s.Require().NoError(err)
+	res = s.fixture.Post(url, tx)
+	s.Require().Equal(http.StatusOK, res.StatusCode, s.readBody(res.Body))
+	s.fixture.DoTick()
+	receipts, err := s.world.GetTransactionReceiptsForTick(currentTick)
+	s.Require().NoError(err)
+	s.Require().Len(receipts, 1)
+	s.Require().Empty(receipts[0].Errs)
+}
+
+func (s *ServerTestSuite) TestTransactionsTargetingTicksBeyondTheHorizonAreRejected() {
+	s.setupWorld()
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	moveMessage, ok := s.world.GetMessageByFullName("game." + moveMsgName)
+	s.Require().True(ok)
+	url := utils.GetTxURL(moveMessage.Group(), moveMessage.Name())
+	horizonTick := s.world.CurrentTick() + cardinal.DefaultTargetTickHorizon
+	payload := MoveMsgInput{Direction: "up"}
+
+	tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload,
+		sign.WithTargetTick(horizonTick+1))
+	s.Require().NoError(err)
+	res := s.fixture.Post(url, tx)
+	s.Require().Equal(http.StatusBadRequest, res.StatusCode)
+	s.Require().Contains(s.readBody(res.Body), txpool.ErrTargetTickTooFar.Error())
+
+	tx, err = sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload,
+		sign.WithTargetTick(horizonTick))
+	s.Require().NoError(err)
+	res = s.fixture.Post(url, tx)
+	s.Require().Equal(http.StatusOK, res.StatusCode, s.readBody(res.Body))
+}
+
+func (s *ServerTestSuite) TestCanWaitForTransactionReceipt() {
+	s.setupWorld()
+	// No system handles this message, so its transactions never get a receipt.
+	type unhandledIn struct{ Direction string }
+	type unhandledOut struct{}
+	s.Require().NoError(cardinal.RegisterMessage[unhandledIn, unhandledOut](s.world, "unhandled"))
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	moveMessage, ok := s.world.GetMessageByFullName("game." + moveMsgName)
+	s.Require().True(ok)
+	url := utils.GetTxURL(moveMessage.Group(), moveMessage.Name())
+
+	tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, MoveMsgInput{Direction: "up"})
+	s.Require().NoError(err)
+	s.nonce++
+	resCh := make(chan *http.Response, 1)
+	go func() {
+		resCh <- s.fixture.Post(url+"?wait=true", tx)
+	}()
+
+	// Keep ticking until the request returns, as the transaction may not have reached the pool before the first tick.
+	var res *http.Response
+	for res == nil {
+		select {
+		case res = <-resCh:
+		case <-time.After(10 * time.Millisecond):
+			s.fixture.DoTick()
+		}
+	}
+	body := s.readBody(res.Body)
+	s.Require().Equal(http.StatusOK, res.StatusCode, body)
+	var txRes handler.PostTransactionResponse
+	s.Require().NoError(json.Unmarshal([]byte(body), &txRes))
+	s.Require().NotNil(txRes.Receipt)
+	s.Require().Equal(txRes.TxHash, txRes.Receipt.TxHash)
+	s.Require().Equal(personaTag, txRes.Receipt.PersonaTag)
+	s.Require().Equal(map[string]any{"Location": map[string]any{"X": float64(0), "Y": float64(1)}}, txRes.Receipt.Result)
+
+	// Without any ticks, waiting for the receipt times out.
+	tx, err = sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, MoveMsgInput{Direction: "up"})
+	s.Require().NoError(err)
+	s.nonce++
+	res = s.fixture.Post(url+"?wait=true&timeout=50", tx)
+	body = s.readBody(res.Body)
+	s.Require().Equal(http.StatusAccepted, res.StatusCode, body)
+	txRes = handler.PostTransactionResponse{}
+	s.Require().NoError(json.Unmarshal([]byte(body), &txRes))
+	s.Require().NotEmpty(txRes.TxHash)
+	s.Require().Nil(txRes.Receipt)
+
+	// Waiting for a transaction that never gets a receipt returns once its tick completes, long before the timeout.
+	tx, err = sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, unhandledIn{Direction: "up"})
+	s.Require().NoError(err)
+	s.nonce++
+	start := time.Now()
+	go func() {
+		resCh <- s.fixture.Post(utils.GetTxURL("game", "unhandled")+"?wait=true&timeout=60000", tx)
+	}()
+	for res = nil; res == nil; {
+		select {
+		case res = <-resCh:
+		case <-time.After(10 * time.Millisecond):
+			s.fixture.DoTick()
+		}
+	}
+	body = s.readBody(res.Body)
+	s.Require().Equal(http.StatusAccepted, res.StatusCode, body)
+	s.Require().Less(time.Since(start), handler.DefaultWaitTimeout)
+}
+
 // Creates a transaction with the given message, and runs it in a tick.
 func (s *ServerTestSuite) runTx(personaTag string, msg types.Message, payload any) {
 	tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload)
@@ -0,0 +1,197 @@
+package server_test
+
+import (
+	"encoding/json"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/persona/msg"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
+)
+
+type HealthComponent struct {
+	HP int
+}
+
+func (HealthComponent) Name() string {
+	return "health"
+}
+
+type AttackMsg struct {
+	Target types.EntityID
+	Damage int
+}
+
+type AttackResult struct {
+	HP int
+}
+
+func (s *ServerTestSuite) TestTransactionsCanBeSimulated() {
+	s.setupWorld()
+	s.Require().NoError(cardinal.RegisterComponent[HealthComponent](s.world))
+	s.Require().NoError(cardinal.RegisterMessage[AttackMsg, AttackResult](s.world, "attack"))
+	var monster types.EntityID
+	err := cardinal.RegisterInitSystems(s.world, func(wCtx cardinal.WorldContext) error {
+		var err error
+		monster, err = cardinal.Create(wCtx, HealthComponent{HP: 10})
+		return err
+	})
+	s.Require().NoError(err)
+	err = cardinal.RegisterSystems(s.world, func(wCtx cardinal.WorldContext) error {
+		return cardinal.EachMessage[AttackMsg, AttackResult](wCtx, func(tx cardinal.TxData[AttackMsg]) (AttackResult, error) {
+			health, err := cardinal.GetComponent[HealthComponent](wCtx, tx.Msg.Target)
+			if err != nil {
+				return AttackResult{}, err
+			}
+			health.HP -= tx.Msg.Damage
+			if health.HP <= 0 {
+				return AttackResult{}, cardinal.Remove(wCtx, tx.Msg.Target)
+			}
+			return AttackResult{HP: health.HP}, cardinal.SetComponent(wCtx, tx.Msg.Target, health)
+		})
+	})
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+
+	simulate := func(attack AttackMsg) (int, handler.SimulateTransactionResponse) {
+		tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, attack)
+		s.Require().NoError(err)
+		res := s.fixture.Post(utils.GetSimulateTxURL("game", "attack"), tx)
+		var body handler.SimulateTransactionResponse
+		if res.StatusCode == fiber.StatusOK {
+			s.Require().NoError(json.Unmarshal([]byte(s.readBody(res.Body)), &body))
+		}
+		return res.StatusCode, body
+	}
+
+	status, res := simulate(AttackMsg{Target: monster, Damage: 3})
+	s.Require().Equal(fiber.StatusOK, status)
+	s.Require().Equal(s.world.CurrentTick(), res.Tick)
+	s.Require().Empty(res.Receipt.Errors)
+	s.Require().Equal(map[string]any{"HP": float64(7)}, res.Receipt.Result)
+	s.Require().Len(res.StateChanges, 1)
+	s.Require().Equal(monster, res.StateChanges[0].ID)
+	s.Require().False(res.StateChanges[0].Created)
+	s.Require().False(res.StateChanges[0].Removed)
+	s.Require().JSONEq(`{"HP":7}`, string(res.StateChanges[0].Components["health"]))
+
+	status, res = simulate(AttackMsg{Target: monster, Damage: 10})
+	s.Require().Equal(fiber.StatusOK, status)
+	s.Require().Len(res.StateChanges, 1)
+	s.Require().True(res.StateChanges[0].Removed)
+
+	status, res = simulate(AttackMsg{Target: monster + 100, Damage: 1})
+	s.Require().Equal(fiber.StatusOK, status)
+	s.Require().NotEmpty(res.Receipt.Errors)
+	s.Require().Empty(res.StateChanges)
+
+	// Simulations are discarded, and do not use the nonce of the transaction.
+	health, err := cardinal.GetComponent[HealthComponent](cardinal.NewReadOnlyWorldContext(s.world), monster)
+	s.Require().NoError(err)
+	s.Require().Equal(10, health.HP)
+	tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce,
+		AttackMsg{Target: monster, Damage: 3})
+	s.Require().NoError(err)
+	s.Require().Equal(fiber.StatusOK, s.fixture.Post(utils.GetTxURL("game", "attack"), tx).StatusCode)
+	s.fixture.DoTick()
+	health, err = cardinal.GetComponent[HealthComponent](cardinal.NewReadOnlyWorldContext(s.world), monster)
+	s.Require().NoError(err)
+	s.Require().Equal(7, health.HP)
+
+	notFound := s.fixture.Post(utils.GetSimulateTxURL("game", "heal"), AttackMsg{})
+	s.Require().Equal(fiber.StatusNotFound, notFound.StatusCode)
+}
+
+func (s *ServerTestSuite) TestSimulationsThatPanicOrOverlapAreRejected() {
+	s.setupWorld()
+	s.Require().NoError(cardinal.RegisterMessage[AttackMsg, AttackResult](s.world, "attack"))
+	started, release := make(chan struct{}), make(chan struct{})
+	err := cardinal.RegisterSystems(s.world, func(wCtx cardinal.WorldContext) error {
+		return cardinal.EachMessage[AttackMsg, AttackResult](wCtx, func(tx cardinal.TxData[AttackMsg]) (AttackResult, error) {
+			switch {
+			case tx.Msg.Damage < 0:
+				panic("negative damage")
+			case tx.Msg.Damage > 100:
+				close(started)
+				<-release
+			}
+			return AttackResult{}, nil
+		})
+	})
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	simulate := func(attack AttackMsg) (int, string) {
+		tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, attack)
+		s.Require().NoError(err)
+		res := s.fixture.Post(utils.GetSimulateTxURL("game", "attack"), tx)
+		return res.StatusCode, s.readBody(res.Body)
+	}
+
+	status, body := simulate(AttackMsg{Damage: -1})
+	s.Require().Equal(fiber.StatusInternalServerError, status)
+	s.Require().Contains(body, "negative damage")
+
+	// Only one simulation runs at a time.
+	done := make(chan int)
+	go func() {
+		status, _ := simulate(AttackMsg{Damage: 101})
+		done <- status
+	}()
+	<-started
+	status, _ = simulate(AttackMsg{Damage: 1})
+	s.Require().Equal(fiber.StatusTooManyRequests, status)
+	close(release)
+	s.Require().Equal(fiber.StatusOK, <-done)
+
+	// The world keeps running after a simulation panics.
+	status, body = simulate(AttackMsg{Damage: 1})
+	s.Require().Equal(fiber.StatusOK, status, body)
+	s.fixture.DoTick()
+}
+
+func (s *ServerTestSuite) TestSimulatedPersonasAreNotCreated() {
+	s.setupWorld(cardinal.WithMetrics())
+	s.fixture.DoTick()
+	systemRuns := func() string {
+		res := s.fixture.Get("/metrics")
+		defer res.Body.Close()
+		runs := regexp.MustCompile(`cardinal_system_duration_seconds_count\{[^}]*createPersonaSystem[^}]*\} (\d+)`).
+			FindStringSubmatch(s.readBody(res.Body))
+		s.Require().Len(runs, 2)
+		return runs[1]
+	}
+	runsBefore := systemRuns()
+
+	createPersona := msg.CreatePersona{PersonaTag: "simulated", SignerAddress: s.signerAddr}
+	tx, err := sign.NewSystemTransaction(s.privateKey, s.world.Namespace(), s.nonce, createPersona)
+	s.Require().NoError(err)
+	res := s.fixture.Post(utils.GetSimulateTxURL("persona", msg.CreatePersonaMessageName), tx)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+	var simulated handler.SimulateTransactionResponse
+	s.Require().NoError(json.Unmarshal([]byte(s.readBody(res.Body)), &simulated))
+	s.Require().Empty(simulated.Receipt.Errors)
+	s.Require().Len(simulated.StateChanges, 1)
+	s.Require().True(simulated.StateChanges[0].Created)
+	// Simulated runs of systems are not recorded in the metrics.
+	s.Require().Equal(runsBefore, systemRuns())
+
+	// The persona was only created in the simulation, so it can be created for real.
+	res = s.fixture.Post(utils.GetTxURL("persona", msg.CreatePersonaMessageName), tx)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+	var submitted handler.PostTransactionResponse
+	s.Require().NoError(json.Unmarshal([]byte(s.readBody(res.Body)), &submitted))
+	s.fixture.DoTick()
+	rec, _, err := s.world.GetTransactionReceipt(types.TxHash(submitted.TxHash))
+	s.Require().NoError(err)
+	s.Require().Empty(rec.Errs)
+	signer, err := s.world.GetSignerForPersonaTag("simulated", 0)
+	s.Require().NoError(err)
+	s.Require().Equal(s.signerAddr, signer)
+}
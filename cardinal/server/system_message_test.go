@@ -0,0 +1,75 @@
+package server_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
+)
+
+type AirdropMsg struct {
+	Amount int
+}
+
+type AirdropResult struct{}
+
+func (s *ServerTestSuite) TestSystemOnlyMessagesRequireTheSystemSigner() {
+	systemKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	s.setupWorld(cardinal.WithSystemSignerAddress(crypto.PubkeyToAddress(systemKey.PublicKey).Hex()))
+	err = cardinal.RegisterMessage[AirdropMsg, AirdropResult](s.world, "airdrop",
+		cardinal.WithMsgSystemOnly[AirdropMsg, AirdropResult]())
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	url := utils.GetTxURL("game", "airdrop")
+	payload := AirdropMsg{Amount: 100}
+
+	tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload)
+	s.Require().NoError(err)
+	res := s.fixture.Post(url, tx)
+	s.Require().Equal(fiber.StatusForbidden, res.StatusCode)
+	s.Require().Contains(s.readBody(res.Body), handler.ErrSystemTransactionRequired.Error())
+
+	// System transactions must be signed by the system signer.
+	tx, err = sign.NewSystemTransaction(s.privateKey, s.world.Namespace(), s.nonce, payload)
+	s.Require().NoError(err)
+	res = s.fixture.Post(url, tx)
+	s.Require().Equal(fiber.StatusBadRequest, res.StatusCode)
+
+	tx, err = sign.NewSystemTransaction(systemKey, s.world.Namespace(), 1, payload)
+	s.Require().NoError(err)
+	res = s.fixture.Post(url, tx)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode, s.readBody(res.Body))
+
+	res = s.fixture.Get("/world")
+	var result handler.GetWorldResponse
+	s.Require().NoError(json.Unmarshal([]byte(s.readBody(res.Body)), &result))
+	s.Require().True(slices.ContainsFunc(result.Messages, func(field types.FieldDetail) bool {
+		return field.Name == "airdrop" && field.SystemOnly
+	}))
+	s.Require().True(slices.ContainsFunc(result.Messages, func(field types.FieldDetail) bool {
+		return field.Name == moveMsgName && !field.SystemOnly
+	}))
+}
+
+func (s *ServerTestSuite) TestSystemOnlyMessagesAreRejectedWithoutASystemSigner() {
+	s.setupWorld()
+	err := cardinal.RegisterMessage[AirdropMsg, AirdropResult](s.world, "airdrop",
+		cardinal.WithMsgSystemOnly[AirdropMsg, AirdropResult]())
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+
+	tx, err := sign.NewSystemTransaction(s.privateKey, s.world.Namespace(), s.nonce, AirdropMsg{Amount: 100})
+	s.Require().NoError(err)
+	res := s.fixture.Post(utils.GetTxURL("game", "airdrop"), tx)
+	s.Require().Equal(fiber.StatusForbidden, res.StatusCode)
+	s.Require().Contains(s.readBody(res.Body), handler.ErrSystemSignerNotSet.Error())
+}
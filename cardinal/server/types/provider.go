@@ -1,24 +1,44 @@
 package types
 
 import (
+	"context"
+
 	"pkg.world.dev/world-engine/cardinal/gamestate"
 	"pkg.world.dev/world-engine/cardinal/receipt"
+	"pkg.world.dev/world-engine/cardinal/txpool"
 	"pkg.world.dev/world-engine/cardinal/types"
 	"pkg.world.dev/world-engine/sign"
 )
 
 type ProviderWorld interface {
 	UseNonce(signerAddress string, nonce uint64) error
+	UseNonces(signerAddress string, nonces []uint64) error
 	GetSignerForPersonaTag(personaTag string, tick uint64) (addr string, err error)
+	ValidateTransaction(id types.MessageID, v any, sig *sign.Transaction) error
+	GetMessageByID(id types.MessageID) (types.Message, bool)
 	AddTransaction(id types.MessageID, v any, sig *sign.Transaction) (uint64, types.TxHash)
+	AddTransactions(txs []txpool.TxData) (uint64, []types.TxHash)
+	SimulateTransaction(id types.MessageID, v any, sig *sign.Transaction) (
+		receipt.Receipt, []types.EntityStateChange, error)
 	Namespace() string
+	SystemSignerAddress() string
 	GetComponentByName(name string) (types.ComponentMetadata, error)
 	StoreReader() gamestate.Reader
 	HandleQuery(group string, name string, bz []byte) ([]byte, error)
+	HandleVersionedQuery(group string, name string, version int, bz []byte) ([]byte, error)
 	CurrentTick() uint64
+	IsGameRunning() bool
+	Health(ctx context.Context) types.HealthStatus
+	WaitForNextTickContext(ctx context.Context) bool
 	ReceiptHistorySize() uint64
 	GetTransactionReceiptsForTick(tick uint64) ([]receipt.Receipt, error)
+	GetTransactionReceiptsForTicks(startTick, endTick uint64) (map[uint64][]receipt.Receipt, error)
+	GetTransactionReceipt(txHash types.TxHash) (receipt.Receipt, uint64, error)
+	EventHistorySize() uint64
+	GetEventsForTicks(startTick, endTick uint64) (map[uint64][][]byte, error)
+	GetPendingTransactions() []txpool.TxData
 	EvaluateCQL(cql string) ([]types.EntityStateElement, error)
 	GetDebugState() ([]types.DebugStateElement, error)
 	BuildQueryFields() []types.FieldDetail
+	BuildEventFields() []types.EventDetail
 }
@@ -1,7 +1,24 @@
 package utils
 
+import "strconv"
+
 func GetQueryURL(group string, name string) string {
 	return "/query/" + group + "/" + name
 }
 
 func GetTxURL(group string, name string) string { return "/tx/" + group + "/" + name }
+
+// GetVersionedQueryURL returns the URL of the given version of a query.
+func GetVersionedQueryURL(group string, version int, name string) string {
+	return "/query/" + group + "/v" + strconv.Itoa(version) + "/" + name
+}
+
+// GetVersionedTxURL returns the URL of the given version of a message.
+func GetVersionedTxURL(group string, version int, name string) string {
+	return "/tx/" + group + "/v" + strconv.Itoa(version) + "/" + name
+}
+
+// GetSimulateTxURL returns the URL that simulates a transaction for a message.
+func GetSimulateTxURL(group string, name string) string {
+	return "/tx/simulate/" + group + "/" + name
+}
@@ -0,0 +1,102 @@
+package server_test
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/filter"
+	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/sign"
+)
+
+type ValidatedMsg struct {
+	Amount int
+}
+
+type ValidatedMsgResult struct{}
+
+func (s *ServerTestSuite) TestMessageValidatorsRejectTransactions() {
+	s.setupWorld()
+	wantStatelessErr := "amount must be positive"
+	wantStateErr := "amount is larger than the number of locations"
+	err := cardinal.RegisterMessage[ValidatedMsg, ValidatedMsgResult](s.world, "validated",
+		cardinal.WithMsgValidator[ValidatedMsg, ValidatedMsgResult](func(msg ValidatedMsg) error {
+			if msg.Amount <= 0 {
+				return errors.New(wantStatelessErr)
+			}
+			return nil
+		}),
+		cardinal.WithMsgStateValidator[ValidatedMsg, ValidatedMsgResult](
+			func(wCtx cardinal.WorldContext, tx cardinal.TxData[ValidatedMsg]) error {
+				count, err := cardinal.NewSearch().Entity(filter.Contains(filter.Component[LocationComponent]())).Count(wCtx)
+				if err != nil {
+					return err
+				}
+				if tx.Msg.Amount > count {
+					return errors.New(wantStateErr)
+				}
+				return nil
+			}),
+	)
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	moveMessage, ok := s.world.GetMessageByFullName("game." + moveMsgName)
+	s.Require().True(ok)
+	// Creates a single location entity.
+	s.runTx(personaTag, moveMessage, MoveMsgInput{Direction: "up"})
+
+	url := utils.GetTxURL("game", "validated")
+	post := func(msg ValidatedMsg) (int, string) {
+		tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, msg)
+		s.Require().NoError(err)
+		res := s.fixture.Post(url, tx)
+		return res.StatusCode, s.readBody(res.Body)
+	}
+
+	status, body := post(ValidatedMsg{Amount: -1})
+	s.Require().Equal(fiber.StatusBadRequest, status)
+	s.Require().Contains(body, wantStatelessErr)
+
+	status, body = post(ValidatedMsg{Amount: 2})
+	s.Require().Equal(fiber.StatusBadRequest, status)
+	s.Require().Contains(body, wantStateErr)
+
+	// The rejected transactions did not use the nonce, so it can be used by a valid transaction.
+	status, body = post(ValidatedMsg{Amount: 1})
+	s.Require().Equal(fiber.StatusOK, status, body)
+}
+
+func (s *ServerTestSuite) TestMessageValidatorsOnlyRunForVerifiedTransactions() {
+	s.setupWorld()
+	validated := 0
+	err := cardinal.RegisterMessage[ValidatedMsg, ValidatedMsgResult](s.world, "validated",
+		cardinal.WithMsgStateValidator[ValidatedMsg, ValidatedMsgResult](
+			func(cardinal.WorldContext, cardinal.TxData[ValidatedMsg]) error {
+				validated++
+				return nil
+			}),
+	)
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+	url := utils.GetTxURL("game", "validated")
+
+	// A transaction signed by someone other than the signer of the persona is rejected before it is validated.
+	otherKey, err := crypto.GenerateKey()
+	s.Require().NoError(err)
+	tx, err := sign.NewTransaction(otherKey, personaTag, s.world.Namespace(), s.nonce, ValidatedMsg{Amount: 1})
+	s.Require().NoError(err)
+	res := s.fixture.Post(url, tx)
+	s.Require().Equal(fiber.StatusBadRequest, res.StatusCode)
+	s.Require().Equal(0, validated)
+
+	tx, err = sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, ValidatedMsg{Amount: 1})
+	s.Require().NoError(err)
+	res = s.fixture.Post(url, tx)
+	s.Require().Equal(fiber.StatusOK, res.StatusCode, s.readBody(res.Body))
+	s.Require().Equal(1, validated)
+}
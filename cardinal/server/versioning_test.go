@@ -0,0 +1,125 @@
+package server_test
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/server/utils"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
+)
+
+type WalkMsgV1 struct {
+	Direction string
+}
+
+type WalkMsg struct {
+	Direction string
+	Steps     int
+}
+
+type WalkResult struct{}
+
+type WalkCountRequestV1 struct{}
+
+type WalkCountReplyV1 struct {
+	Count int
+}
+
+type WalkCountRequest struct {
+	MinSteps int
+}
+
+type WalkCountReply struct {
+	Count int
+	Steps int
+}
+
+func (s *ServerTestSuite) TestMessagesAndQueriesCanBeVersioned() {
+	s.setupWorld()
+	err := cardinal.RegisterMessage[WalkMsg, WalkResult](s.world, "walk",
+		cardinal.WithMsgVersion[WalkMsg, WalkResult](2),
+		cardinal.WithMsgPreviousVersion[WalkMsgV1, WalkMsg, WalkResult](1, func(v1 WalkMsgV1) (WalkMsg, error) {
+			return WalkMsg{Direction: v1.Direction, Steps: 1}, nil
+		}))
+	s.Require().NoError(err)
+	var walks []WalkMsg
+	err = cardinal.RegisterSystems(s.world, func(wCtx cardinal.WorldContext) error {
+		return cardinal.EachMessage[WalkMsg, WalkResult](wCtx, func(tx cardinal.TxData[WalkMsg]) (WalkResult, error) {
+			walks = append(walks, tx.Msg)
+			return WalkResult{}, nil
+		})
+	})
+	s.Require().NoError(err)
+	err = cardinal.RegisterQuery[WalkCountRequest, WalkCountReply](s.world, "walk-count",
+		func(_ cardinal.WorldContext, req *WalkCountRequest) (*WalkCountReply, error) {
+			reply := &WalkCountReply{}
+			for _, walk := range walks {
+				if walk.Steps >= req.MinSteps {
+					reply.Count++
+					reply.Steps += walk.Steps
+				}
+			}
+			return reply, nil
+		},
+		cardinal.WithQueryVersion[WalkCountRequest, WalkCountReply](2),
+		cardinal.WithQueryPreviousVersion[WalkCountRequestV1, WalkCountReplyV1, WalkCountRequest, WalkCountReply](1,
+			func(WalkCountRequestV1) (WalkCountRequest, error) {
+				return WalkCountRequest{MinSteps: 0}, nil
+			},
+			func(reply WalkCountReply) (WalkCountReplyV1, error) {
+				return WalkCountReplyV1{Count: reply.Count}, nil
+			}))
+	s.Require().NoError(err)
+	s.fixture.DoTick()
+	personaTag := s.CreateRandomPersona()
+
+	post := func(url string, payload any) int {
+		tx, err := sign.NewTransaction(s.privateKey, personaTag, s.world.Namespace(), s.nonce, payload)
+		s.Require().NoError(err)
+		res := s.fixture.Post(url, tx)
+		if res.StatusCode == fiber.StatusOK {
+			s.nonce++
+		}
+		return res.StatusCode
+	}
+	// The latest version is served with and without a version, and older versions are converted to it.
+	s.Require().Equal(fiber.StatusOK, post(utils.GetVersionedTxURL("game", 1, "walk"), WalkMsgV1{Direction: "up"}))
+	s.Require().Equal(fiber.StatusOK,
+		post(utils.GetVersionedTxURL("game", 2, "walk"), WalkMsg{Direction: "left", Steps: 3}))
+	s.Require().Equal(fiber.StatusOK, post(utils.GetTxURL("game", "walk"), WalkMsg{Direction: "down", Steps: 2}))
+	s.Require().Equal(fiber.StatusNotFound, post(utils.GetVersionedTxURL("game", 3, "walk"), WalkMsg{Steps: 1}))
+	s.Require().Equal(fiber.StatusNotFound, post("/tx/game/latest/walk", WalkMsg{Steps: 1}))
+	s.fixture.DoTick()
+	s.Require().ElementsMatch([]WalkMsg{
+		{Direction: "up", Steps: 1},
+		{Direction: "left", Steps: 3},
+		{Direction: "down", Steps: 2},
+	}, walks)
+
+	res := s.fixture.Post(utils.GetVersionedQueryURL("game", 1, "walk-count"), WalkCountRequestV1{})
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+	s.Require().JSONEq(`{"Count":3}`, s.readBody(res.Body))
+	res = s.fixture.Post(utils.GetQueryURL("game", "walk-count"), WalkCountRequest{MinSteps: 2})
+	s.Require().Equal(fiber.StatusOK, res.StatusCode)
+	s.Require().JSONEq(`{"Count":2,"Steps":5}`, s.readBody(res.Body))
+	res = s.fixture.Post(utils.GetVersionedQueryURL("game", 3, "walk-count"), WalkCountRequest{})
+	s.Require().Equal(fiber.StatusNotFound, res.StatusCode)
+
+	res = s.fixture.Get("/world")
+	var result handler.GetWorldResponse
+	s.Require().NoError(json.Unmarshal([]byte(s.readBody(res.Body)), &result))
+	hasVersion := func(fields []types.FieldDetail, name string, version int, url string) bool {
+		return slices.ContainsFunc(fields, func(field types.FieldDetail) bool {
+			return field.Name == name && field.Version == version && field.URL == url
+		})
+	}
+	s.Require().True(hasVersion(result.Messages, "walk", 2, utils.GetTxURL("game", "walk")))
+	s.Require().True(hasVersion(result.Messages, "walk", 1, utils.GetVersionedTxURL("game", 1, "walk")))
+	s.Require().True(hasVersion(result.Queries, "walk-count", 2, utils.GetQueryURL("game", "walk-count")))
+	s.Require().True(hasVersion(result.Queries, "walk-count", 1, utils.GetVersionedQueryURL("game", 1, "walk-count")))
+}
@@ -29,6 +29,24 @@ func TestUseNonce(t *testing.T) {
 	assert.NilError(t, rs.UseNonce(address, nonce))
 }
 
+func TestUseNoncesIsAllOrNothing(t *testing.T) {
+	rs := GetRedisStorage(t)
+	addr := "some-address"
+	assert.NilError(t, rs.UseNonce(addr, 2))
+
+	// Nonce 2 has already been used, so neither 1 nor 3 should be marked as used.
+	err := rs.UseNonces(addr, []uint64{1, 2, 3})
+	assert.ErrorIs(t, redis.ErrNonceHasAlreadyBeenUsed, err)
+
+	// Duplicate nonces in the same call are rejected.
+	err = rs.UseNonces(addr, []uint64{1, 1})
+	assert.ErrorIs(t, redis.ErrNonceHasAlreadyBeenUsed, err)
+
+	assert.NilError(t, rs.UseNonces(addr, []uint64{1, 3}))
+	assert.ErrorIs(t, redis.ErrNonceHasAlreadyBeenUsed, rs.UseNonce(addr, 1))
+	assert.ErrorIs(t, redis.ErrNonceHasAlreadyBeenUsed, rs.UseNonce(addr, 3))
+}
+
 func TestCanStoreManyNonces(t *testing.T) {
 	rs := GetRedisStorage(t)
 	for i := uint64(10); i < 100; i++ {
@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rotisserie/eris"
+)
+
+// EventStorage persists the events that were broadcast in each tick. The events of a tick are stored as opaque bytes
+// keyed by the tick, and the ticks are indexed so that old events can be looked up by range and removed.
+type EventStorage struct {
+	Client *redis.Client
+}
+
+func NewEventStorage(client *redis.Client) EventStorage {
+	return EventStorage{
+		Client: client,
+	}
+}
+
+// SetEvents stores the events of the given tick.
+func (r *EventStorage) SetEvents(tick uint64, events []byte) error {
+	ctx := context.Background()
+	tickStr := strconv.FormatUint(tick, 10)
+	pipe := r.Client.TxPipeline()
+	pipe.HSet(ctx, r.eventsKey(), tickStr, events)
+	pipe.ZAdd(ctx, r.eventTicksKey(), redis.Z{Score: float64(tick), Member: tickStr})
+	_, err := pipe.Exec(ctx)
+	return eris.Wrap(err, "")
+}
+
+// GetEventsForTicks returns the events of the ticks in the range [startTick, endTick), keyed by tick. Ticks without
+// stored events are omitted.
+func (r *EventStorage) GetEventsForTicks(startTick, endTick uint64) (map[uint64][]byte, error) {
+	ctx := context.Background()
+	ticks, err := r.Client.ZRangeByScore(ctx, r.eventTicksKey(), &redis.ZRangeBy{
+		Min: strconv.FormatUint(startTick, 10),
+		Max: "(" + strconv.FormatUint(endTick, 10),
+	}).Result()
+	if err != nil {
+		return nil, eris.Wrap(err, "")
+	}
+	events := map[uint64][]byte{}
+	if len(ticks) == 0 {
+		return events, nil
+	}
+	values, err := r.Client.HMGet(ctx, r.eventsKey(), ticks...).Result()
+	if err != nil {
+		return nil, eris.Wrap(err, "")
+	}
+	for i, value := range values {
+		// Events can be missing if they are removed between the two calls above.
+		data, ok := value.(string)
+		if !ok {
+			continue
+		}
+		tick, err := strconv.ParseUint(ticks[i], 10, 64)
+		if err != nil {
+			return nil, eris.Wrapf(err, "unexpected event tick member %q", ticks[i])
+		}
+		events[tick] = []byte(data)
+	}
+	return events, nil
+}
+
+// DeleteEventsBefore removes the events of all the ticks before the given tick.
+func (r *EventStorage) DeleteEventsBefore(tick uint64) error {
+	ctx := context.Background()
+	maxScore := "(" + strconv.FormatUint(tick, 10)
+	ticks, err := r.Client.ZRangeByScore(ctx, r.eventTicksKey(), &redis.ZRangeBy{Min: "-inf", Max: maxScore}).Result()
+	if err != nil {
+		return eris.Wrap(err, "")
+	}
+	if len(ticks) == 0 {
+		return nil
+	}
+	pipe := r.Client.TxPipeline()
+	pipe.HDel(ctx, r.eventsKey(), ticks...)
+	pipe.ZRem(ctx, r.eventTicksKey(), anySlice(ticks)...)
+	_, err = pipe.Exec(ctx)
+	return eris.Wrap(err, "")
+}
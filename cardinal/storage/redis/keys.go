@@ -14,3 +14,42 @@ func (r *NonceStorage) nonceSetKey(str string) string {
 func (r *SchemaStorage) schemaStorageKey() string {
 	return "COMPONENT_NAME_TO_SCHEMA_DATA"
 }
+
+/*
+	TX POOL STORAGE:    TX_HASH -> Transaction waiting in the transaction pool.
+	Hash set of tx hash to an encoded transaction, and hash set of tx hash to the tick the transaction was included in.
+*/
+
+func (r *TxPoolStorage) pendingTxsKey() string {
+	return "PENDING_TXS"
+}
+
+func (r *TxPoolStorage) pendingTxTicksKey() string {
+	return "PENDING_TX_TICKS"
+}
+
+/*
+	RECEIPT STORAGE:    TX_HASH -> Receipt of an executed transaction.
+	Hash set of tx hash to an encoded receipt, and sorted set of tx hashes scored by the tick the receipt was produced in.
+*/
+
+func (r *ReceiptStorage) receiptsKey() string {
+	return "RECEIPTS"
+}
+
+func (r *ReceiptStorage) receiptTicksKey() string {
+	return "RECEIPT_TICKS"
+}
+
+/*
+	EVENT STORAGE:      TICK -> Events broadcast in a tick.
+	Hash set of tick to the encoded events of the tick, and sorted set of ticks scored by the tick.
+*/
+
+func (r *EventStorage) eventsKey() string {
+	return "EVENTS"
+}
+
+func (r *EventStorage) eventTicksKey() string {
+	return "EVENT_TICKS"
+}
@@ -104,6 +104,77 @@ func (r *NonceStorage) UseNonce(signerAddress string, nonce uint64) error {
 	return nil
 }
 
+// UseNonces atomically marks all the given nonces as used. Either all nonces are marked as used and nil is returned,
+// or none of them are marked as used and an error is returned.
+func (r *NonceStorage) UseNonces(signerAddress string, nonces []uint64) error {
+	ctx := context.Background()
+	signerAddressKey := r.nonceSetKey(signerAddress)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	maxNonce, err := r.getMaxNonceForKey(ctx, signerAddressKey)
+	if err != nil {
+		return eris.Wrap(err, "failed to get max nonce for signer address")
+	}
+
+	seen := make(map[uint64]bool, len(nonces))
+	zItems := make([]redis.Z, 0, len(nonces))
+	for _, nonce := range nonces {
+		if nonce > maxValidNonce {
+			return eris.New("nonce is too large")
+		}
+		if nonce < maxNonce && maxNonce-nonce >= NonceSlidingWindowSize {
+			return eris.New("nonce is too old")
+		}
+		if seen[nonce] {
+			return eris.Wrapf(ErrNonceHasAlreadyBeenUsed, "signer %q has used nonce %d more than once", signerAddress,
+				nonce)
+		}
+		seen[nonce] = true
+		zItems = append(zItems, redis.Z{
+			Score:  float64(nonce),
+			Member: nonce,
+		})
+	}
+
+	// Make sure none of the nonces have been used before adding any of them.
+	pipe := r.Client.Pipeline()
+	scores := make([]*redis.FloatCmd, 0, len(nonces))
+	for _, nonce := range nonces {
+		scores = append(scores, pipe.ZScore(ctx, signerAddressKey, strconv.FormatUint(nonce, 10)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return eris.Wrap(err, "failed to check nonces")
+	}
+	for i, score := range scores {
+		if err := score.Err(); err == nil {
+			return eris.Wrapf(ErrNonceHasAlreadyBeenUsed, "signer %q has already used nonce %d", signerAddress,
+				nonces[i])
+		} else if !errors.Is(err, redis.Nil) {
+			return eris.Wrap(err, "failed to check nonce")
+		}
+	}
+
+	if len(zItems) == 0 {
+		return nil
+	}
+	if err := r.Client.ZAdd(ctx, signerAddressKey, zItems...).Err(); err != nil {
+		return eris.Wrap(err, "failed to add nonces")
+	}
+
+	for _, nonce := range nonces {
+		r.maxNonce[signerAddressKey] = max(r.maxNonce[signerAddressKey], nonce)
+	}
+	r.countNonce[signerAddressKey] += len(nonces)
+
+	if r.countNonce[signerAddressKey] > numOfNoncesToTriggerCleanup {
+		r.cleanupOldNonces(ctx, signerAddressKey, r.maxNonce[signerAddressKey])
+	}
+
+	return nil
+}
+
 // cleanupOldNonces removes the record of all nonces that are older than NonceSlidingWindowSize. Nonces in that range
 // can be rejected without checking storage. ZRemRangeByScore has a performance of O(log(N)+M) where N is the number
 // of items in the set and M is the number of items to remove.
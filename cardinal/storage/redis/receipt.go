@@ -0,0 +1,126 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rotisserie/eris"
+)
+
+var (
+	ErrNoReceiptFound = errors.New("no receipt found")
+)
+
+// ReceiptStorage persists transaction receipts. Receipts are stored as opaque bytes keyed by tx hash, and are indexed
+// by the tick they were produced in so that old receipts can be looked up by tick and removed.
+type ReceiptStorage struct {
+	Client *redis.Client
+}
+
+func NewReceiptStorage(client *redis.Client) ReceiptStorage {
+	return ReceiptStorage{
+		Client: client,
+	}
+}
+
+// SetReceipts stores the given receipts, keyed by tx hash, for the given tick.
+func (r *ReceiptStorage) SetReceipts(tick uint64, receipts map[string][]byte) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	values := make([]any, 0, len(receipts)*2) //nolint:gomnd // each receipt is a key and a value
+	members := make([]redis.Z, 0, len(receipts))
+	for txHash, data := range receipts {
+		values = append(values, txHash, data)
+		members = append(members, redis.Z{Score: float64(tick), Member: txHash})
+	}
+	pipe := r.Client.TxPipeline()
+	pipe.HSet(ctx, r.receiptsKey(), values...)
+	pipe.ZAdd(ctx, r.receiptTicksKey(), members...)
+	_, err := pipe.Exec(ctx)
+	return eris.Wrap(err, "")
+}
+
+// GetReceipt returns the receipt for the given tx hash, along with the tick it was produced in. If no receipt is
+// found, ErrNoReceiptFound is returned.
+func (r *ReceiptStorage) GetReceipt(txHash string) ([]byte, uint64, error) {
+	ctx := context.Background()
+	data, err := r.Client.HGet(ctx, r.receiptsKey(), txHash).Bytes()
+	if eris.Is(err, redis.Nil) {
+		return nil, 0, eris.Wrap(ErrNoReceiptFound, "")
+	} else if err != nil {
+		return nil, 0, eris.Wrap(err, "")
+	}
+	score, err := r.Client.ZScore(ctx, r.receiptTicksKey(), txHash).Result()
+	if eris.Is(err, redis.Nil) {
+		return nil, 0, eris.Wrap(ErrNoReceiptFound, "")
+	} else if err != nil {
+		return nil, 0, eris.Wrap(err, "")
+	}
+	return data, uint64(score), nil
+}
+
+// GetReceiptsForTicks returns all the receipts produced in the ticks in the range [startTick, endTick), keyed by tick.
+func (r *ReceiptStorage) GetReceiptsForTicks(startTick, endTick uint64) (map[uint64][][]byte, error) {
+	ctx := context.Background()
+	members, err := r.Client.ZRangeByScoreWithScores(ctx, r.receiptTicksKey(), &redis.ZRangeBy{
+		Min: strconv.FormatUint(startTick, 10),
+		Max: "(" + strconv.FormatUint(endTick, 10),
+	}).Result()
+	if err != nil {
+		return nil, eris.Wrap(err, "")
+	}
+	receipts := map[uint64][][]byte{}
+	if len(members) == 0 {
+		return receipts, nil
+	}
+	txHashes := make([]string, 0, len(members))
+	for _, member := range members {
+		txHash, ok := member.Member.(string)
+		if !ok {
+			return nil, eris.Errorf("unexpected receipt tick member %v", member.Member)
+		}
+		txHashes = append(txHashes, txHash)
+	}
+	values, err := r.Client.HMGet(ctx, r.receiptsKey(), txHashes...).Result()
+	if err != nil {
+		return nil, eris.Wrap(err, "")
+	}
+	for i, value := range values {
+		// A receipt can be missing if it is removed between the two calls above.
+		if data, ok := value.(string); ok {
+			tick := uint64(members[i].Score)
+			receipts[tick] = append(receipts[tick], []byte(data))
+		}
+	}
+	return receipts, nil
+}
+
+// DeleteReceiptsBefore removes all the receipts produced before the given tick.
+func (r *ReceiptStorage) DeleteReceiptsBefore(tick uint64) error {
+	ctx := context.Background()
+	maxScore := "(" + strconv.FormatUint(tick, 10)
+	txHashes, err := r.Client.ZRangeByScore(ctx, r.receiptTicksKey(), &redis.ZRangeBy{Min: "-inf", Max: maxScore}).Result()
+	if err != nil {
+		return eris.Wrap(err, "")
+	}
+	if len(txHashes) == 0 {
+		return nil
+	}
+	pipe := r.Client.TxPipeline()
+	pipe.HDel(ctx, r.receiptsKey(), txHashes...)
+	pipe.ZRem(ctx, r.receiptTicksKey(), anySlice(txHashes)...)
+	_, err = pipe.Exec(ctx)
+	return eris.Wrap(err, "")
+}
+
+func anySlice(strs []string) []any {
+	res := make([]any, 0, len(strs))
+	for _, s := range strs {
+		res = append(res, s)
+	}
+	return res
+}
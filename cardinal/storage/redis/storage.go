@@ -15,6 +15,9 @@ type Storage struct {
 	Log       zerolog.Logger
 	NonceStorage
 	SchemaStorage
+	TxPoolStorage
+	ReceiptStorage
+	EventStorage
 }
 
 type Options = redis.Options
@@ -22,11 +25,14 @@ type Options = redis.Options
 func NewRedisStorage(options Options, namespace string) Storage {
 	client := redis.NewClient(&options)
 	return Storage{
-		Namespace:     namespace,
-		Client:        client,
-		Log:           zerolog.New(os.Stdout),
-		NonceStorage:  NewNonceStorage(client),
-		SchemaStorage: NewSchemaStorage(client),
+		Namespace:      namespace,
+		Client:         client,
+		Log:            zerolog.New(os.Stdout),
+		NonceStorage:   NewNonceStorage(client),
+		SchemaStorage:  NewSchemaStorage(client),
+		TxPoolStorage:  NewTxPoolStorage(client),
+		ReceiptStorage: NewReceiptStorage(client),
+		EventStorage:   NewEventStorage(client),
 	}
 }
 
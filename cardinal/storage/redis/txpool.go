@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rotisserie/eris"
+)
+
+// TxPoolStorage persists the transactions that are waiting in the transaction pool so that they are not lost if
+// Cardinal restarts before they are executed. Transactions are stored as opaque bytes keyed by their tx hash. Once a
+// transaction is included in a tick, it is tagged with that tick so that a restart can tell whether the transaction
+// has already been executed.
+type TxPoolStorage struct {
+	Client *redis.Client
+}
+
+func NewTxPoolStorage(client *redis.Client) TxPoolStorage {
+	return TxPoolStorage{
+		Client: client,
+	}
+}
+
+// AddPendingTransactions stores the given transactions, keyed by tx hash.
+func (r *TxPoolStorage) AddPendingTransactions(txs map[string][]byte) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	values := make([]any, 0, len(txs)*2) //nolint:gomnd // each tx is a key and a value
+	for txHash, data := range txs {
+		values = append(values, txHash, data)
+	}
+	return eris.Wrap(r.Client.HSet(ctx, r.pendingTxsKey(), values...).Err(), "")
+}
+
+// SetPendingTransactionsTick tags the given transactions with the tick they are included in.
+func (r *TxPoolStorage) SetPendingTransactionsTick(txHashes []string, tick uint64) error {
+	if len(txHashes) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	values := make([]any, 0, len(txHashes)*2) //nolint:gomnd // each tx is a key and a value
+	for _, txHash := range txHashes {
+		values = append(values, txHash, tick)
+	}
+	return eris.Wrap(r.Client.HSet(ctx, r.pendingTxTicksKey(), values...).Err(), "")
+}
+
+// RemovePendingTransactions removes the given transactions and their tick tags.
+func (r *TxPoolStorage) RemovePendingTransactions(txHashes []string) error {
+	if len(txHashes) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	pipe := r.Client.TxPipeline()
+	pipe.HDel(ctx, r.pendingTxsKey(), txHashes...)
+	pipe.HDel(ctx, r.pendingTxTicksKey(), txHashes...)
+	_, err := pipe.Exec(ctx)
+	return eris.Wrap(err, "")
+}
+
+// GetPendingTransactions returns all stored transactions keyed by tx hash, along with the tick each transaction was
+// tagged with. Transactions that have not been tagged are absent from the returned ticks.
+func (r *TxPoolStorage) GetPendingTransactions() (txs map[string][]byte, ticks map[string]uint64, err error) {
+	ctx := context.Background()
+	rawTxs, err := r.Client.HGetAll(ctx, r.pendingTxsKey()).Result()
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "")
+	}
+	rawTicks, err := r.Client.HGetAll(ctx, r.pendingTxTicksKey()).Result()
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "")
+	}
+
+	txs = make(map[string][]byte, len(rawTxs))
+	for txHash, data := range rawTxs {
+		txs[txHash] = []byte(data)
+	}
+	ticks = make(map[string]uint64, len(rawTicks))
+	for txHash, rawTick := range rawTicks {
+		tick, err := strconv.ParseUint(rawTick, 10, 64)
+		if err != nil {
+			return nil, nil, eris.Wrapf(err, "invalid tick for pending transaction %q", txHash)
+		}
+		ticks[txHash] = tick
+	}
+	return txs, ticks, nil
+}
@@ -2,6 +2,7 @@ package storage
 
 type NonceStorage interface {
 	UseNonce(signerAddress string, nonce uint64) error
+	UseNonces(signerAddress string, nonces []uint64) error
 }
 
 type SchemaStorage interface {
@@ -9,8 +10,24 @@ type SchemaStorage interface {
 	SetSchema(componentName string, schemaData []byte) error
 }
 
+type TxPoolStorage interface {
+	AddPendingTransactions(txs map[string][]byte) error
+	SetPendingTransactionsTick(txHashes []string, tick uint64) error
+	RemovePendingTransactions(txHashes []string) error
+	GetPendingTransactions() (txs map[string][]byte, ticks map[string]uint64, err error)
+}
+
+type ReceiptStorage interface {
+	SetReceipts(tick uint64, receipts map[string][]byte) error
+	GetReceipt(txHash string) ([]byte, uint64, error)
+	GetReceiptsForTicks(startTick, endTick uint64) (map[uint64][][]byte, error)
+	DeleteReceiptsBefore(tick uint64) error
+}
+
 type Storage interface {
 	NonceStorage
 	SchemaStorage
+	TxPoolStorage
+	ReceiptStorage
 	Close() error
 }
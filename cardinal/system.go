@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"runtime"
 	"slices"
+	"time"
 
 	"github.com/rotisserie/eris"
 	"go.opentelemetry.io/otel"
@@ -13,6 +14,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	ddotel "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/opentelemetry"
 	ddtracer "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+
+	"pkg.world.dev/world-engine/cardinal/telemetry"
 )
 
 const (
@@ -53,15 +56,17 @@ type systemManager struct {
 	// currentSystem is the name of the system that is currently running.
 	currentSystem string
 
-	tracer trace.Tracer
+	tracer  trace.Tracer
+	metrics *telemetry.Metrics
 }
 
-func newSystemManager() SystemManager {
+func newSystemManager(metrics *telemetry.Metrics) SystemManager {
 	var sm SystemManager = &systemManager{
 		registeredSystems:     make([]systemType, 0),
 		registeredInitSystems: make([]systemType, 0),
 		currentSystem:         noActiveSystemName,
 		tracer:                otel.Tracer("system"),
+		metrics:               metrics,
 	}
 	return sm
 }
@@ -136,6 +141,7 @@ func (m *systemManager) runSystems(ctx context.Context, wCtx WorldContext) error
 		_, systemFnSpan := m.tracer.Start(ddotel.ContextWithStartOptions(ctx, //nolint:spancheck // false positive
 			ddtracer.Measured()),
 			"system.run."+sys.Name)
+		systemStartTime := time.Now()
 		if err := sys.Fn(wCtx); err != nil {
 			m.currentSystem = ""
 			span.SetStatus(codes.Error, eris.ToString(err, true))
@@ -145,6 +151,10 @@ func (m *systemManager) runSystems(ctx context.Context, wCtx WorldContext) error
 			return eris.Wrapf(err, "System %s generated an error", sys.Name) //nolint:spancheck // false positive
 		}
 		systemFnSpan.End()
+		// Simulated runs are not recorded, so the metrics only reflect the ticks of the world.
+		if !wCtx.isSimulation() {
+			m.metrics.ObserveSystem(sys.Name, time.Since(systemStartTime))
+		}
 	}
 
 	// Reset the logger to the original logger
@@ -0,0 +1,175 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/redis/go-redis/v9"
+	"github.com/rotisserie/eris"
+)
+
+const (
+	metricsPrefix = "cardinal"
+	// unknownMessage is the message label of the receipts of transactions whose message is not registered.
+	unknownMessage = "unknown"
+	// pipelineCommand is the command label of Redis pipelines and transactions.
+	pipelineCommand = "pipeline"
+)
+
+var (
+	// tickBuckets range from 1ms to ~4s.
+	tickBuckets = prometheus.ExponentialBuckets(0.001, 2, 12)
+	// operationBuckets are the buckets of systems and Redis commands, which range from 100µs to ~1.6s.
+	operationBuckets = prometheus.ExponentialBuckets(0.0001, 2, 14)
+)
+
+// Metrics are the Prometheus metrics of a world. Each world has its own registry, and every metric in it is labeled
+// with the namespace of the world, so that the metrics of several shards can be told apart.
+type Metrics struct {
+	registry   *prometheus.Registry
+	registerer prometheus.Registerer
+
+	tickDuration         prometheus.Histogram
+	systemDuration       *prometheus.HistogramVec
+	tickTransactions     prometheus.Gauge
+	transactions         *prometheus.CounterVec
+	receiptErrors        *prometheus.CounterVec
+	routerSubmitDuration prometheus.Histogram
+	redisDuration        *prometheus.HistogramVec
+}
+
+// NewMetrics returns the metrics of the world with the given namespace, along with the metrics of the Go runtime and
+// the process.
+func NewMetrics(namespace string) *Metrics {
+	registry := prometheus.NewRegistry()
+	m := &Metrics{
+		registry:   registry,
+		registerer: prometheus.WrapRegistererWith(prometheus.Labels{"namespace": namespace}, registry),
+		tickDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsPrefix,
+			Name:      "tick_duration_seconds",
+			Help:      "Duration of the ticks of the world.",
+			Buckets:   tickBuckets,
+		}),
+		systemDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsPrefix,
+			Name:      "system_duration_seconds",
+			Help:      "Duration of each system in a tick.",
+			Buckets:   operationBuckets,
+		}, []string{"system"}),
+		tickTransactions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsPrefix,
+			Name:      "tick_transactions",
+			Help:      "Number of transactions executed by the last tick.",
+		}),
+		transactions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsPrefix,
+			Name:      "transactions_total",
+			Help:      "Number of transactions executed, by message.",
+		}, []string{"message"}),
+		receiptErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsPrefix,
+			Name:      "receipt_errors_total",
+			Help:      "Number of transactions whose receipt has errors, by message.",
+		}, []string{"message"}),
+		routerSubmitDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsPrefix,
+			Name:      "router_submit_duration_seconds",
+			Help:      "Duration of the submissions of the transactions of a tick to the base shard.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+		redisDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsPrefix,
+			Name:      "redis_duration_seconds",
+			Help:      "Duration of Redis commands, by command.",
+			Buckets:   operationBuckets,
+		}, []string{"command"}),
+	}
+	m.registerer.MustRegister(
+		m.tickDuration, m.systemDuration, m.tickTransactions, m.transactions, m.receiptErrors, m.routerSubmitDuration,
+		m.redisDuration,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Gatherer returns the registry of the metrics, which is served on /metrics.
+func (m *Metrics) Gatherer() prometheus.Gatherer {
+	return m.registry
+}
+
+// ObserveTick records the duration of a tick and the number of transactions it executed.
+func (m *Metrics) ObserveTick(duration time.Duration, txCount int) {
+	m.tickDuration.Observe(duration.Seconds())
+	m.tickTransactions.Set(float64(txCount))
+}
+
+// ObserveSystem records the duration of a system.
+func (m *Metrics) ObserveSystem(name string, duration time.Duration) {
+	m.systemDuration.WithLabelValues(name).Observe(duration.Seconds())
+}
+
+// ObserveReceipt counts an executed transaction of the message with the given full name, and whether it failed.
+func (m *Metrics) ObserveReceipt(msgName string, failed bool) {
+	if msgName == "" {
+		msgName = unknownMessage
+	}
+	m.transactions.WithLabelValues(msgName).Inc()
+	if failed {
+		m.receiptErrors.WithLabelValues(msgName).Inc()
+	}
+}
+
+// ObserveRouterSubmission records the duration of a submission of transactions to the base shard.
+func (m *Metrics) ObserveRouterSubmission(duration time.Duration) {
+	m.routerSubmitDuration.Observe(duration.Seconds())
+}
+
+// RegisterWebsocketConnections reports the number of open websocket connections of an endpoint, as returned by count.
+func (m *Metrics) RegisterWebsocketConnections(endpoint string, count func() int) error {
+	gauge := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   metricsPrefix,
+		Name:        "websocket_connections",
+		Help:        "Number of open websocket connections, by endpoint.",
+		ConstLabels: prometheus.Labels{"endpoint": endpoint},
+	}, func() float64 {
+		return float64(count())
+	})
+	return eris.Wrap(m.registerer.Register(gauge), "failed to register websocket connections metric")
+}
+
+// RedisHook returns a hook that records the duration of the commands of a Redis client.
+func (m *Metrics) RedisHook() redis.Hook {
+	return redisHook{duration: m.redisDuration}
+}
+
+type redisHook struct {
+	duration *prometheus.HistogramVec
+}
+
+var _ redis.Hook = redisHook{}
+
+func (h redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.duration.WithLabelValues(cmd.Name()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+func (h redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.duration.WithLabelValues(pipelineCommand).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
@@ -1,26 +1,78 @@
 package telemetry
 
 import (
+	"context"
 	"errors"
 
+	"github.com/rotisserie/eris"
 	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	ddotel "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/opentelemetry"
 	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
 	"gopkg.in/DataDog/dd-trace-go.v1/profiler"
 )
 
+const (
+	// TraceExporterDatadog sends traces to a DataDog agent.
+	TraceExporterDatadog = "datadog"
+	// TraceExporterOTLP sends traces to an OpenTelemetry collector over OTLP.
+	TraceExporterOTLP = "otlp"
+
+	// OTLPProtocolGRPC exports traces over OTLP/gRPC.
+	OTLPProtocolGRPC = "grpc"
+	// OTLPProtocolHTTP exports traces over OTLP/HTTP.
+	OTLPProtocolHTTP = "http"
+
+	serviceName = "cardinal"
+)
+
+var ErrInvalidOTLPProtocol = errors.New("OTLP protocol must be " + OTLPProtocolGRPC + " or " + OTLPProtocolHTTP)
+
+// OTLPConfig configures the export of traces over OTLP. The endpoint falls back to the standard
+// OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variables, and headers can be set with
+// OTEL_EXPORTER_OTLP_HEADERS.
+type OTLPConfig struct {
+	// Protocol is either OTLPProtocolGRPC or OTLPProtocolHTTP.
+	Protocol string
+	// Endpoint is the host and port of the collector, e.g. localhost:4317.
+	Endpoint string
+	// Insecure disables TLS.
+	Insecure bool
+	// Namespace is the namespace of the world, which is reported as the service.namespace of the traces.
+	Namespace string
+}
+
 type Manager struct {
 	tracerShutdownFunc   func() error
 	profilerShutdownFunc func()
 	tracerProvider       *ddotel.TracerProvider
+	otlpConfig           *OTLPConfig
+}
+
+type Option func(*Manager)
+
+// WithOTLPTraceExporter exports traces over OTLP rather than to a DataDog agent. The profiler still runs on DataDog.
+func WithOTLPTraceExporter(cfg OTLPConfig) Option {
+	return func(tm *Manager) {
+		tm.otlpConfig = &cfg
+	}
 }
 
-func New(enableTrace bool, enableProfiler bool) (*Manager, error) {
+func New(enableTrace bool, enableProfiler bool, opts ...Option) (*Manager, error) {
 	tm := Manager{
 		tracerShutdownFunc: nil,
 		tracerProvider:     nil,
+		otlpConfig:         nil,
+	}
+	for _, opt := range opts {
+		opt(&tm)
 	}
 
 	// Set up propagator
@@ -28,7 +80,13 @@ func New(enableTrace bool, enableProfiler bool) (*Manager, error) {
 
 	// Set up trace provider used for creating spans
 	if enableTrace {
-		tm.setupTrace()
+		if tm.otlpConfig != nil {
+			if err := tm.setupOTLPTrace(); err != nil {
+				return nil, err
+			}
+		} else {
+			tm.setupTrace()
+		}
 	}
 
 	// Set up profiler
@@ -46,15 +104,16 @@ func New(enableTrace bool, enableProfiler bool) (*Manager, error) {
 func (tm *Manager) Shutdown() error {
 	log.Debug().Msg("Shutting down telemetry")
 
+	// The profiler is stopped first, as it keeps running on DataDog even if traces are exported over OTLP.
+	if tm.profilerShutdownFunc != nil {
+		tm.profilerShutdownFunc()
+	}
+
 	if tm.tracerShutdownFunc != nil {
 		err := tm.tracerShutdownFunc()
 		return err
 	}
 
-	if tm.profilerShutdownFunc != nil {
-		tm.profilerShutdownFunc()
-	}
-
 	log.Debug().Msg("Successfully shutdown telemetry")
 	return nil
 }
@@ -73,6 +132,53 @@ func (tm *Manager) setupTrace() {
 	otel.SetTracerProvider(tm.tracerProvider)
 }
 
+// setupOTLPTrace sets up a trace provider that batches spans and exports them to an OpenTelemetry collector.
+func (tm *Manager) setupOTLPTrace() error {
+	var client otlptrace.Client
+	switch tm.otlpConfig.Protocol {
+	case OTLPProtocolGRPC, "":
+		var opts []otlptracegrpc.Option
+		if tm.otlpConfig.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(tm.otlpConfig.Endpoint))
+		}
+		if tm.otlpConfig.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		client = otlptracegrpc.NewClient(opts...)
+	case OTLPProtocolHTTP:
+		var opts []otlptracehttp.Option
+		if tm.otlpConfig.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(tm.otlpConfig.Endpoint))
+		}
+		if tm.otlpConfig.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		client = otlptracehttp.NewClient(opts...)
+	default:
+		return eris.Wrap(ErrInvalidOTLPProtocol, "")
+	}
+
+	// The exporter connects lazily, so Cardinal starts even if the collector is not reachable yet.
+	exporter, err := otlptrace.New(context.Background(), client)
+	if err != nil {
+		return eris.Wrap(err, "failed to create OTLP trace exporter")
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.namespace", tm.otlpConfig.Namespace),
+	))
+	if err != nil {
+		return eris.Wrap(err, "failed to create trace resource")
+	}
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	tm.tracerShutdownFunc = func() error {
+		return tp.Shutdown(context.Background())
+	}
+	otel.SetTracerProvider(tp)
+	return nil
+}
+
 func (tm *Manager) setupProfiler() error {
 	err := profiler.Start(
 		profiler.WithProfileTypes(
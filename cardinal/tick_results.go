@@ -6,19 +6,27 @@ import (
 	"github.com/rotisserie/eris"
 
 	"pkg.world.dev/world-engine/cardinal/receipt"
+	"pkg.world.dev/world-engine/cardinal/types"
 )
 
 type TickResults struct {
 	Tick     uint64
 	Receipts []receipt.Receipt
 	Events   [][]byte
+	// privateEvents holds the events that are only sent to a single persona, keyed by persona tag. They are left out
+	// when the tick results are broadcast.
+	privateEvents map[string][][]byte
+	// stateChanges holds the changes to the entities that websocket connections subscribed to. They are only sent to
+	// those connections.
+	stateChanges []types.EntityStateChange
 }
 
 func NewTickResults(initialTick uint64) *TickResults {
 	return &TickResults{
-		Tick:     initialTick,
-		Receipts: []receipt.Receipt{},
-		Events:   [][]byte{},
+		Tick:          initialTick,
+		Receipts:      []receipt.Receipt{},
+		Events:        [][]byte{},
+		privateEvents: map[string][][]byte{},
 	}
 }
 
@@ -36,6 +44,24 @@ func (tr *TickResults) AddStringEvent(e string) error {
 	return nil
 }
 
+// AddPrivateEvent adds an event that is only sent to the given persona.
+func (tr *TickResults) AddPrivateEvent(personaTag string, event any) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return eris.Wrap(err, "must use a json serializable type for emitting events")
+	}
+	if tr.privateEvents == nil {
+		tr.privateEvents = map[string][][]byte{}
+	}
+	tr.privateEvents[personaTag] = append(tr.privateEvents[personaTag], data)
+	return nil
+}
+
+// PrivateEvents returns the events that are only sent to a single persona, keyed by persona tag.
+func (tr *TickResults) PrivateEvents() map[string][][]byte {
+	return tr.privateEvents
+}
+
 func (tr *TickResults) SetReceipts(newReceipts []receipt.Receipt) {
 	tr.Receipts = newReceipts
 }
@@ -44,8 +70,30 @@ func (tr *TickResults) SetTick(tick uint64) {
 	tr.Tick = tick
 }
 
+func (tr *TickResults) SetStateChanges(changes []types.EntityStateChange) {
+	tr.stateChanges = changes
+}
+
+func (tr *TickResults) GetTick() uint64 {
+	return tr.Tick
+}
+
+func (tr *TickResults) GetReceipts() []receipt.Receipt {
+	return tr.Receipts
+}
+
+func (tr *TickResults) GetEvents() [][]byte {
+	return tr.Events
+}
+
+func (tr *TickResults) GetStateChanges() []types.EntityStateChange {
+	return tr.stateChanges
+}
+
 func (tr *TickResults) Clear() {
 	tr.Tick = 0
 	tr.Receipts = nil
 	tr.Events = nil
+	tr.privateEvents = nil
+	tr.stateChanges = nil
 }
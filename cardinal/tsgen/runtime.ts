@@ -0,0 +1,164 @@
+export const SYSTEM_PERSONA_TAG = "SystemPersonaTag";
+
+// SignedTransaction is a transaction signed the same way as sign.Transaction. Body is the normalized JSON of the
+// message, which is sent as is since the signature covers its exact bytes.
+export interface SignedTransaction {
+  personaTag: string;
+  namespace: string;
+  nonce: number;
+  signature: string;
+  hash: string;
+  body: string;
+  targetTick?: number;
+  expiryTick?: number;
+}
+
+// TransactionOptions are the optional fields of a transaction, which are included in its signature when they are set.
+export interface TransactionOptions {
+  // targetTick is the tick the transaction should be executed in.
+  targetTick?: number;
+  // expiryTick is the last tick the transaction can be executed in.
+  expiryTick?: number;
+}
+
+export interface ReceiptEntry {
+  txHash: string;
+  tick: number;
+  personaTag: string;
+  messageName: string;
+  result: unknown;
+  errors: string[] | null;
+}
+
+export interface PostTransactionResponse {
+  TxHash: string;
+  Tick: number;
+  Receipt?: ReceiptEntry;
+}
+
+function sortKeys(value: unknown): unknown {
+  if (Array.isArray(value)) {
+    return value.map(sortKeys);
+  }
+  if (value !== null && typeof value === "object") {
+    const sorted: Record<string, unknown> = {};
+    for (const key of Object.keys(value).sort()) {
+      sorted[key] = sortKeys((value as Record<string, unknown>)[key]);
+    }
+    return sorted;
+  }
+  return value;
+}
+
+// normalizeBody encodes a message the way Go's encoding/json encodes it once it has been decoded into a map: with
+// sorted keys and HTML characters escaped. Signatures are checked against this encoding.
+export function normalizeBody(body: unknown): string {
+  return JSON.stringify(sortKeys(body))
+    .replace(/</g, "\\u003c")
+    .replace(/>/g, "\\u003e")
+    .replace(/&/g, "\\u0026")
+    .replace(/\u2028/g, "\\u2028")
+    .replace(/\u2029/g, "\\u2029");
+}
+
+// transactionHash returns the hash that is signed for a transaction, which matches sign.Transaction's hash.
+export function transactionHash(
+  personaTag: string,
+  namespace: string,
+  nonce: number,
+  body: string,
+  options: TransactionOptions = {},
+): string {
+  const data = [toUtf8Bytes(personaTag), toUtf8Bytes(namespace), toUtf8Bytes(String(nonce)), toUtf8Bytes(body)];
+  if (options.targetTick) {
+    data.push(toUtf8Bytes(`targetTick:${options.targetTick}`));
+  }
+  if (options.expiryTick) {
+    data.push(toUtf8Bytes(`expiryTick:${options.expiryTick}`));
+  }
+  return keccak256(concat(data));
+}
+
+// signTransaction signs a message on behalf of a persona with the hex encoded private key of its signer.
+export function signTransaction(
+  privateKey: string,
+  personaTag: string,
+  nonce: number,
+  message: unknown,
+  options: TransactionOptions = {},
+): SignedTransaction {
+  const body = normalizeBody(message);
+  const hash = transactionHash(personaTag, NAMESPACE, nonce, body, options);
+  const signature = new SigningKey(privateKey).sign(hash).serialized;
+  return {
+    personaTag,
+    namespace: NAMESPACE,
+    nonce,
+    signature: signature.slice(2),
+    hash,
+    body,
+    ...(options.targetTick ? { targetTick: options.targetTick } : {}),
+    ...(options.expiryTick ? { expiryTick: options.expiryTick } : {}),
+  };
+}
+
+// signSystemTransaction signs a message with the system persona tag, e.g. to create a persona.
+export function signSystemTransaction(
+  privateKey: string,
+  nonce: number,
+  message: unknown,
+  options: TransactionOptions = {},
+): SignedTransaction {
+  return signTransaction(privateKey, SYSTEM_PERSONA_TAG, nonce, message, options);
+}
+
+// transactionJSON encodes a signed transaction, keeping the exact bytes of its signed body.
+export function transactionJSON(tx: SignedTransaction): string {
+  const { body, ...fields } = tx;
+  return `${JSON.stringify(fields).slice(0, -1)},"body":${body}}`;
+}
+
+export class CardinalError extends Error {
+  constructor(readonly status: number, message: string) {
+    super(message);
+  }
+}
+
+export class BaseClient {
+  constructor(readonly url: string, readonly privateKey?: string) {}
+
+  protected signer(): string {
+    if (!this.privateKey) {
+      throw new Error("a private key is required to send messages");
+    }
+    return this.privateKey;
+  }
+
+  protected async post<T>(path: string, body: string): Promise<T> {
+    const res = await fetch(this.url + path, {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body,
+    });
+    if (!res.ok) {
+      throw new CardinalError(res.status, await res.text());
+    }
+    return (await res.json()) as T;
+  }
+
+  async sendTransaction(path: string, tx: SignedTransaction): Promise<PostTransactionResponse> {
+    return this.post<PostTransactionResponse>(path, transactionJSON(tx));
+  }
+
+  async query<T>(path: string, request: unknown): Promise<T> {
+    return this.post<T>(path, JSON.stringify(request));
+  }
+
+  async getReceipt(txHash: string): Promise<ReceiptEntry> {
+    const res = await fetch(`${this.url}/receipt/${txHash}`);
+    if (!res.ok) {
+      throw new CardinalError(res.status, await res.text());
+    }
+    return (await res.json()) as ReceiptEntry;
+  }
+}
@@ -0,0 +1,251 @@
+// Package tsgen generates a typed TypeScript client for the messages, queries, components and events of a world.
+//
+// The generated client signs transactions the same way as the sign package, using ethers v6, so it can submit
+// messages to Cardinal without hand-written types or signing code.
+package tsgen
+
+import (
+	_ "embed" // for the runtime of the generated client.
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+//go:embed runtime.ts
+var runtime string
+
+const header = `// Code generated by cardinal-tsgen. DO NOT EDIT.
+
+import { SigningKey, concat, keccak256, toUtf8Bytes } from "ethers";
+`
+
+// GenerateFromWorld writes the TypeScript client of the messages, queries, components and events registered in the
+// world. The world doesn't need to be started.
+func GenerateFromWorld(w io.Writer, world *cardinal.World) error {
+	details := handler.NewWorldDetails(world.GetRegisteredComponents(), world.GetRegisteredMessages())
+	return Generate(w, details.Response(world, world.Namespace()))
+}
+
+// Generate writes the TypeScript client of the world described by the response of /world.
+func Generate(w io.Writer, world handler.GetWorldResponse) error {
+	var b strings.Builder
+	printf := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+	}
+	printf("%s\nexport const NAMESPACE = %s;\n\n%s", header, strconv.Quote(world.Namespace), runtime)
+
+	components := sortedByName(world.Components)
+	if len(components) > 0 {
+		printf("\n// Components\n")
+	}
+	for _, comp := range components {
+		// Components are often named after their Go type, e.g. SignerComponent.
+		name := strings.TrimSuffix(pascalCase(comp.Name), "Component") + "Component"
+		printf("\nexport interface %s %s\n", name, fieldsType(comp.Fields, ""))
+	}
+
+	messages := sortedByURL(world.Messages)
+	if len(messages) > 0 {
+		printf("\n// Messages\n")
+	}
+	for _, msg := range messages {
+		printf("\nexport interface %sMessage %s\n", routeName(msg.URL), fieldsType(msg.Fields, ""))
+	}
+
+	queries := sortedByURL(world.Queries)
+	if len(queries) > 0 {
+		printf("\n// Queries\n")
+	}
+	for _, query := range queries {
+		printf("\nexport interface %sRequest %s\n", routeName(query.URL), fieldsType(query.Fields, ""))
+	}
+
+	if len(world.Events) > 0 {
+		printf("\n// Events\n")
+		eventTypes := make([]string, 0, len(world.Events))
+		for _, event := range world.Events {
+			name := pascalCase(event.Name) + "Event"
+			printf("\nexport interface %s %s\n", name, fieldsType(event.Fields, ""))
+			eventTypes = append(eventTypes, fmt.Sprintf("{ type: %s; data: %s }", strconv.Quote(event.Name), name))
+		}
+		printf("\nexport type TypedEvent =\n  | %s;\n", strings.Join(eventTypes, "\n  | "))
+	}
+
+	printf("\n// CardinalClient has a method for each message and query of the world.\n")
+	printf("export class CardinalClient extends BaseClient {")
+	for _, msg := range messages {
+		name := routeName(msg.URL)
+		if msg.SystemOnly {
+			printf(`
+  async send%[1]s(
+    nonce: number,
+    message: %[1]sMessage,
+    options?: TransactionOptions,
+  ): Promise<PostTransactionResponse> {
+    return this.sendTransaction(%[2]s, signSystemTransaction(this.signer(), nonce, message, options));
+  }
+`, name, strconv.Quote(msg.URL))
+			continue
+		}
+		printf(`
+  async send%[1]s(
+    personaTag: string,
+    nonce: number,
+    message: %[1]sMessage,
+    options?: TransactionOptions,
+  ): Promise<PostTransactionResponse> {
+    return this.sendTransaction(%[2]s, signTransaction(this.signer(), personaTag, nonce, message, options));
+  }
+`, name, strconv.Quote(msg.URL))
+	}
+	for _, query := range queries {
+		printf(`
+  async query%[1]s<Reply = unknown>(request: %[1]sRequest): Promise<Reply> {
+    return this.query<Reply>(%[2]s, request);
+  }
+`, routeName(query.URL), strconv.Quote(query.URL))
+	}
+	printf("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return eris.Wrap(err, "failed to write TypeScript client")
+}
+
+// fieldsType returns the TypeScript type of an object with the given field information, as returned by
+// types.GetFieldInformation.
+func fieldsType(fields map[string]any, indent string) string {
+	if len(fields) == 0 {
+		return "{}"
+	}
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, tag := range names {
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		optional := ""
+		if strings.Contains(opts, "omitempty") {
+			optional = "?"
+		}
+		var fieldType string
+		if nested, ok := fields[tag].(map[string]any); ok {
+			fieldType = fieldsType(nested, indent+"  ")
+		} else {
+			goType, _ := fields[tag].(string)
+			fieldType = goTypeToTS(goType)
+		}
+		fmt.Fprintf(&b, "%s  %s%s: %s;\n", indent, propertyName(name), optional, fieldType)
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// goTypeToTS returns the TypeScript type of the JSON encoding of a Go type, given the name reflect gives the type.
+// Named types whose underlying type is unknown are typed as unknown.
+func goTypeToTS(goType string) string {
+	switch {
+	case goType == "string" || goType == "time.Time" || goType == "[]uint8" || goType == "[]byte":
+		return "string"
+	case goType == "bool":
+		return "boolean"
+	case isNumber(goType):
+		return "number"
+	case strings.HasPrefix(goType, "*"):
+		return goTypeToTS(goType[1:]) + " | null"
+	case strings.HasPrefix(goType, "["):
+		// Slices and arrays, e.g. []int and [4]int
+		if _, elem, ok := strings.Cut(goType, "]"); ok {
+			return arrayType(goTypeToTS(elem))
+		}
+	case strings.HasPrefix(goType, "map["):
+		if _, elem, ok := strings.Cut(goType, "]"); ok {
+			return "Record<string, " + goTypeToTS(elem) + ">"
+		}
+	}
+	return "unknown"
+}
+
+func arrayType(elem string) string {
+	if strings.Contains(elem, " ") {
+		return "(" + elem + ")[]"
+	}
+	return elem + "[]"
+}
+
+func isNumber(goType string) bool {
+	switch strings.TrimPrefix(goType, "u") {
+	case "int", "int8", "int16", "int32", "int64", "float32", "float64":
+		return true
+	}
+	return goType == "types.EntityID"
+}
+
+// propertyName quotes the name of a property if it isn't a valid identifier.
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return strconv.Quote(name)
+		}
+	}
+	if name == "" {
+		return `""`
+	}
+	return name
+}
+
+// routeName returns the name of a message or query route, e.g. GameMove for /tx/game/move and GameMoveV1 for
+// /tx/game/v1/move.
+func routeName(url string) string {
+	parts := strings.Split(strings.Trim(url, "/"), "/")[1:]
+	if len(parts) == 3 {
+		// Versioned routes are /<group>/<version>/<name>
+		parts = []string{parts[0], parts[2], parts[1]}
+	}
+	return pascalCase(strings.Join(parts, "-"))
+}
+
+// pascalCase converts a name such as create-persona to CreatePersona.
+func pascalCase(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func sortedByName(details []types.FieldDetail) []types.FieldDetail {
+	sorted := append([]types.FieldDetail(nil), details...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted
+}
+
+func sortedByURL(details []types.FieldDetail) []types.FieldDetail {
+	sorted := append([]types.FieldDetail(nil), details...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].URL < sorted[j].URL })
+	return sorted
+}
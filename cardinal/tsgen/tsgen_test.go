@@ -0,0 +1,74 @@
+package tsgen_test
+
+import (
+	"strings"
+	"testing"
+
+	"pkg.world.dev/world-engine/assert"
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/tsgen"
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+type Health struct {
+	HP    int      `json:"hp"`
+	Owner string   `json:"owner,omitempty"`
+	Tags  []string `json:"tags"`
+}
+
+func (Health) Name() string { return "health" }
+
+type AttackMsg struct {
+	Target types.EntityID
+	Power  *float64
+}
+
+type AttackResult struct {
+	Damage int
+}
+
+type HealthRequest struct {
+	Entity types.EntityID `json:"entity"`
+}
+
+type HealthReply struct {
+	HP int
+}
+
+type DeathEvent struct {
+	Entity types.EntityID `json:"entity"`
+}
+
+func TestClientHasTypesAndMethodsOfTheWorld(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil)
+	world := tf.World
+	assert.NilError(t, cardinal.RegisterComponent[Health](world))
+	assert.NilError(t, cardinal.RegisterMessage[AttackMsg, AttackResult](world, "attack"))
+	assert.NilError(t, cardinal.RegisterQuery[HealthRequest, HealthReply](world, "health",
+		func(cardinal.WorldContext, *HealthRequest) (*HealthReply, error) {
+			return &HealthReply{}, nil
+		}))
+	assert.NilError(t, cardinal.RegisterEvent[DeathEvent](world, "death"))
+
+	var b strings.Builder
+	assert.NilError(t, tsgen.GenerateFromWorld(&b, world))
+	client := b.String()
+
+	for _, want := range []string{
+		`export const NAMESPACE = "` + world.Namespace() + `";`,
+		"export interface SignerComponent {\n  AuthorizedAddresses: string[];\n  PersonaTag: string;\n" +
+			"  SignerAddress: string;\n}",
+		"export interface HealthComponent {\n  hp: number;\n  owner?: string;\n  tags: string[];\n}",
+		"export interface GameAttackMessage {\n  Power: number | null;\n  Target: number;\n}",
+		"export interface GameHealthRequest {\n  entity: number;\n}",
+		"export interface DeathEvent {\n  entity: number;\n}",
+		`{ type: "death"; data: DeathEvent }`,
+		`async sendGameAttack(`,
+		`return this.sendTransaction("/tx/game/attack", signTransaction(`,
+		`return this.sendTransaction("/tx/persona/create-persona", signTransaction(`,
+		`async queryGameHealth<Reply = unknown>(request: GameHealthRequest): Promise<Reply> {`,
+		`export function signTransaction(`,
+	} {
+		assert.Check(t, strings.Contains(client, want), "client is missing %q", want)
+	}
+}
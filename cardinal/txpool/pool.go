@@ -2,8 +2,11 @@ package txpool
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"sync"
 
+	"github.com/rotisserie/eris"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 	ddotel "gopkg.in/DataDog/dd-trace-go.v1/ddtrace/opentelemetry"
@@ -13,6 +16,15 @@ import (
 	"pkg.world.dev/world-engine/sign"
 )
 
+var (
+	// ErrTransactionExpired is returned when a transaction can no longer be executed because its expiry tick has
+	// passed.
+	ErrTransactionExpired = errors.New("transaction expired")
+	// ErrTargetTickTooFar is returned when a transaction targets a tick further beyond the tick of the pool than the
+	// target tick horizon of the pool.
+	ErrTargetTickTooFar = errors.New("target tick is too far in the future")
+)
+
 type TxMap map[types.MessageID][]TxData
 
 type TxData struct {
@@ -20,6 +32,9 @@ type TxData struct {
 	Msg    any
 	TxHash types.TxHash
 	Tx     *sign.Transaction
+	// MsgVersion is the version of the message the body of Tx was encoded with. Msg is always the latest version of
+	// the message. 0 means the latest version.
+	MsgVersion int
 	// EVMSourceTxHash is the tx hash of the EVM tx that triggered this tx.
 	EVMSourceTxHash string
 }
@@ -27,15 +42,27 @@ type TxData struct {
 type TxPool struct {
 	m         TxMap
 	txsInPool int
-	mux       *sync.Mutex
-	tracer    trace.Tracer
+	// tick is the tick the transactions in m will be executed in. It is advanced each time the pool is copied, so a
+	// transaction is always added to the pool for the tick it will actually be executed in.
+	tick uint64
+	// delayed holds the transactions that target a specific tick, keyed by that tick. They are moved into m when the
+	// pool is copied for their target tick.
+	delayed map[uint64][]TxData
+	// targetTickHorizon is how many ticks beyond tick a transaction can target. 0 means there is no limit.
+	targetTickHorizon uint64
+	// expired holds the transactions that expired before they could be executed. It is only populated in copies of the
+	// pool.
+	expired []TxData
+	mux     *sync.Mutex
+	tracer  trace.Tracer
 }
 
 func New() *TxPool {
 	return &TxPool{
-		m:      TxMap{},
-		mux:    &sync.Mutex{},
-		tracer: otel.Tracer("txpool"),
+		m:       TxMap{},
+		delayed: map[uint64][]TxData{},
+		mux:     &sync.Mutex{},
+		tracer:  otel.Tracer("txpool"),
 	}
 }
 
@@ -61,50 +88,216 @@ func (t *TxPool) GetEVMTxs() []TxData {
 	return transactions
 }
 
-func (t *TxPool) AddTransaction(id types.MessageID, v any, sig *sign.Transaction) types.TxHash {
-	return t.addTransaction(id, v, sig, "")
+// SetTick sets the tick the transactions in the pool will be executed in.
+func (t *TxPool) SetTick(tick uint64) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.tick = tick
 }
 
-func (t *TxPool) AddEVMTransaction(id types.MessageID, v any, sig *sign.Transaction, evmTxHash string) types.TxHash {
-	return t.addTransaction(id, v, sig, evmTxHash)
+// SetTargetTickHorizon sets how many ticks beyond the tick of the pool a transaction can target. Delayed transactions
+// are held in memory until their target tick, so the horizon bounds how long they can accumulate. 0 means there is no
+// limit.
+func (t *TxPool) SetTargetTickHorizon(horizon uint64) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	t.targetTickHorizon = horizon
 }
 
-func (t *TxPool) addTransaction(id types.MessageID, v any, sig *sign.Transaction, evmTxHash string) types.TxHash {
+// ValidateTicks returns an error if the given transaction cannot be admitted to the pool because of its target or
+// expiry tick. The ticks are checked against the tick the pool's transactions will be executed in, which is ahead of
+// the world's current tick while a tick is running.
+func (t *TxPool) ValidateTicks(sig *sign.Transaction) error {
 	t.mux.Lock()
 	defer t.mux.Unlock()
+	if sig.ExpiryTick != 0 && sig.ExpiryTick < t.tick {
+		return eris.Wrapf(ErrTransactionExpired, "expired at tick %d, next tick is %d", sig.ExpiryTick, t.tick)
+	}
+	if t.targetTickHorizon > 0 && sig.TargetTick > t.tick+t.targetTickHorizon {
+		return eris.Wrapf(ErrTargetTickTooFar, "target tick %d is more than %d ticks beyond tick %d", sig.TargetTick,
+			t.targetTickHorizon, t.tick)
+	}
+	return nil
+}
+
+// AddTransaction adds a transaction to the pool. Returns the tick the transaction will be executed in.
+func (t *TxPool) AddTransaction(id types.MessageID, v any, sig *sign.Transaction) (uint64, types.TxHash) {
+	return t.addTransaction(TxData{MsgID: id, Msg: v, Tx: sig})
+}
+
+func (t *TxPool) AddEVMTransaction(
+	id types.MessageID, v any, sig *sign.Transaction, evmTxHash string,
+) (uint64, types.TxHash) {
+	return t.addTransaction(TxData{MsgID: id, Msg: v, Tx: sig, EVMSourceTxHash: evmTxHash})
+}
+
+// AddTransactions adds all the given transactions to the pool at once, guaranteeing that they will be included in
+// the same copy of the pool (provided they share the same target tick). Returns the latest tick any of the
+// transactions will be executed in.
+func (t *TxPool) AddTransactions(txs []TxData) (uint64, []types.TxHash) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	tick := t.tick
+	txHashes := make([]types.TxHash, 0, len(txs))
+	for _, tx := range txs {
+		txTick, txHash := t.add(tx)
+		tick = max(tick, txTick)
+		txHashes = append(txHashes, txHash)
+	}
+	return tick, txHashes
+}
+
+func (t *TxPool) addTransaction(txData TxData) (uint64, types.TxHash) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+	return t.add(txData)
+}
+
+// add adds a transaction to the pool, and returns the tick it will be executed in. The hash of the transaction is
+// computed from txData.Tx.
+// NOTE: the mutex must be held when calling this method.
+func (t *TxPool) add(txData TxData) (uint64, types.TxHash) {
+	sig := txData.Tx
 	txHash := types.TxHash(sig.HashHex())
-	t.m[id] = append(t.m[id], TxData{
-		MsgID:           id,
-		TxHash:          txHash,
-		Msg:             v,
-		Tx:              sig,
-		EVMSourceTxHash: evmTxHash,
-	})
+	txData.TxHash = txHash
+	if sig.TargetTick > 0 {
+		t.delayed[sig.TargetTick] = append(t.delayed[sig.TargetTick], txData)
+		// Transactions that target a tick that has already been copied are released in the next copy.
+		return max(sig.TargetTick, t.tick), txHash
+	}
+	t.m[txData.MsgID] = append(t.m[txData.MsgID], txData)
 	t.txsInPool++
-	return txHash
+	return t.tick, txHash
 }
 
 func (t *TxPool) Transactions() TxMap {
 	return t.m
 }
 
-// CopyTransactions returns a copy of the TxPool, and resets the state to 0 values.
-func (t *TxPool) CopyTransactions(ctx context.Context) *TxPool {
+// PendingTransactions returns the transactions that are waiting for their target tick, ordered by target tick.
+func (t *TxPool) PendingTransactions() []TxData {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	pending := make([]TxData, 0)
+	for _, tick := range t.sortedDelayedTicks() {
+		pending = append(pending, t.delayed[tick]...)
+	}
+	return pending
+}
+
+// ExpiredTransactions returns the transactions that were removed from this copy of the pool because they expired
+// before the tick the copy was made for.
+func (t *TxPool) ExpiredTransactions() []TxData {
+	return t.expired
+}
+
+// CopyTransactions returns a copy of the TxPool for the given tick, and resets the state to 0 values. Delayed
+// transactions that target the given tick (or an earlier one) are included in the copy, ahead of the transactions
+// that were submitted without a target tick. Transactions that expired before the given tick are not included in the
+// copy's transactions; they can be retrieved with ExpiredTransactions instead. Transactions added after the copy is
+// made are executed in the following tick.
+func (t *TxPool) CopyTransactions(ctx context.Context, tick uint64) *TxPool {
 	_, span := t.tracer.Start(ddotel.ContextWithStartOptions(ctx, ddtracer.Measured()), "txpool.copy-transactions")
 	defer span.End()
 
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
+	t.releaseDelayed(tick)
+	t.removeExpired(tick)
 	cpy := *t
+	cpy.delayed = nil
+	cpy.tick = tick
 	t.reset()
+	t.tick = tick + 1
 
 	return &cpy
 }
 
+// Restore puts the transactions of a copy made by CopyTransactions back into the pool, ahead of the transactions that
+// were added since the copy was made, and moves the pool back to the tick of the copy. It undoes the copy when its tick
+// cannot run, so that its transactions are not lost.
+func (t *TxPool) Restore(cpy *TxPool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	restored := TxMap{}
+	for id, txs := range cpy.m {
+		restored[id] = append(restored[id], txs...)
+	}
+	// Expired transactions are restored too, so they are removed from the pool again (and get their receipts) when the
+	// pool is next copied.
+	for _, tx := range cpy.expired {
+		restored[tx.MsgID] = append(restored[tx.MsgID], tx)
+	}
+	for id, txs := range t.m {
+		restored[id] = append(restored[id], txs...)
+	}
+	t.m = restored
+	t.txsInPool += cpy.txsInPool + len(cpy.expired)
+	t.tick = cpy.tick
+}
+
+// removeExpired moves the transactions that can no longer be executed in the given tick out of the pool.
+// NOTE: the mutex must be held when calling this method.
+func (t *TxPool) removeExpired(tick uint64) {
+	for id, txs := range t.m {
+		live := txs[:0]
+		for _, tx := range txs {
+			if tx.Tx != nil && tx.Tx.ExpiryTick != 0 && tx.Tx.ExpiryTick < tick {
+				t.expired = append(t.expired, tx)
+				t.txsInPool--
+				continue
+			}
+			live = append(live, tx)
+		}
+		if len(live) == 0 {
+			delete(t.m, id)
+		} else {
+			t.m[id] = live
+		}
+	}
+}
+
+// releaseDelayed moves the delayed transactions that target the given tick (or an earlier one) into the pool.
+// NOTE: the mutex must be held when calling this method.
+func (t *TxPool) releaseDelayed(tick uint64) {
+	released := TxMap{}
+	count := 0
+	for _, target := range t.sortedDelayedTicks() {
+		if target > tick {
+			break
+		}
+		for _, tx := range t.delayed[target] {
+			released[tx.MsgID] = append(released[tx.MsgID], tx)
+			count++
+		}
+		delete(t.delayed, target)
+	}
+	if count == 0 {
+		return
+	}
+	for id, txs := range t.m {
+		released[id] = append(released[id], txs...)
+	}
+	t.m = released
+	t.txsInPool += count
+}
+
+func (t *TxPool) sortedDelayedTicks() []uint64 {
+	ticks := make([]uint64, 0, len(t.delayed))
+	for tick := range t.delayed {
+		ticks = append(ticks, tick)
+	}
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i] < ticks[j] })
+	return ticks
+}
+
 func (t *TxPool) reset() {
 	t.m = TxMap{}
 	t.txsInPool = 0
+	t.expired = nil
 }
 
 func (t *TxPool) ForID(id types.MessageID) []TxData {
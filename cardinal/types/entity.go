@@ -13,3 +13,13 @@ type DebugStateElement struct {
 	ID         EntityID                   `json:"id"`
 	Components map[string]json.RawMessage `json:"components" swaggertype:"object"`
 }
+
+// EntityStateChange describes how the state of an entity changed. Components holds the new values of the components
+// that were added or modified, and RemovedComponents holds the names of the components that were removed.
+type EntityStateChange struct {
+	ID                EntityID                   `json:"id"`
+	Created           bool                       `json:"created,omitempty"`
+	Removed           bool                       `json:"removed,omitempty"`
+	Components        map[string]json.RawMessage `json:"components,omitempty" swaggertype:"object"`
+	RemovedComponents []string                   `json:"removedComponents,omitempty"`
+}
@@ -3,3 +3,6 @@ package types
 import "github.com/rotisserie/eris"
 
 var ErrQueryNotFound = eris.New("query not found")
+
+// ErrSimulationInProgress is returned when a transaction is simulated while another simulation is running.
+var ErrSimulationInProgress = eris.New("another transaction is being simulated")
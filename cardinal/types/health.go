@@ -0,0 +1,46 @@
+package types
+
+// HealthStatus is the state of a world and of the services it depends on, which is reported by /health and /ready.
+type HealthStatus struct {
+	// Stage is the worldstage of the world, e.g. Recovering, Running or ShuttingDown.
+	Stage string `json:"stage"`
+	// LastTick is the last tick that was completed. It is omitted until the world completes a tick.
+	LastTick *TickStatus `json:"lastTick,omitempty"`
+	// Redis is the connectivity of the storage of the world.
+	Redis ServiceStatus `json:"redis"`
+	// Router is the connectivity of the base shard. It is omitted if the world is not in rollup mode.
+	Router *ServiceStatus `json:"router,omitempty"`
+	// Recovery is the progress of the replay of the transactions of the base shard. It is omitted if the world did not
+	// recover from the base shard.
+	Recovery *RecoveryProgress `json:"recovery,omitempty"`
+}
+
+// TickStatus is a completed tick and how long ago it was run.
+type TickStatus struct {
+	Tick uint64 `json:"tick"`
+	// Timestamp is the unix timestamp of the tick in milliseconds. The timestamps of recovered ticks are the ones they
+	// originally ran at.
+	Timestamp uint64 `json:"timestamp"`
+	// AgeMs is the number of milliseconds since the timestamp of the tick.
+	AgeMs int64 `json:"ageMs"`
+}
+
+// ServiceStatus is the connectivity of a service the world depends on.
+type ServiceStatus struct {
+	Connected bool `json:"connected"`
+	// State is the state of the connection, e.g. the gRPC connectivity state of the router.
+	State string `json:"state,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// RecoveryProgress is the progress of the recovery of a world from the base shard.
+type RecoveryProgress struct {
+	// StartTick is the tick the recovery started from.
+	StartTick uint64 `json:"startTick"`
+	// CurrentTick is the tick that is being replayed.
+	CurrentTick uint64 `json:"currentTick"`
+	// ReplayedTicks is the number of ticks whose transactions have been replayed.
+	ReplayedTicks uint64 `json:"replayedTicks"`
+	// Complete is true once every transaction of the base shard has been replayed.
+	Complete bool `json:"complete"`
+}
@@ -1,8 +1,21 @@
 package types
 
+import "encoding/json"
+
 // FieldDetail represents a field from a url request.
 type FieldDetail struct {
 	Name   string         `json:"name"`   // name of the message or query
 	Fields map[string]any `json:"fields"` // variable name and type
 	URL    string         `json:"url,omitempty"`
+	// Version is the version of the message or query. Older versions are listed separately from the latest version.
+	Version int `json:"version,omitempty"`
+	// SystemOnly is set for messages that can only be sent with a system transaction.
+	SystemOnly bool `json:"systemOnly,omitempty"`
+}
+
+// EventDetail describes an event type registered with RegisterEvent. Schema is the JSON schema of the event data.
+type EventDetail struct {
+	Name   string          `json:"name"`
+	Fields map[string]any  `json:"fields"`
+	Schema json.RawMessage `json:"schema" swaggertype:"object"`
 }
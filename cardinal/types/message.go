@@ -18,6 +18,13 @@ type Message interface {
 	ABIEncode(any) ([]byte, error)
 	// IsEVMCompatible reports if this message can be sent from the EVM.
 	IsEVMCompatible() bool
+	// Version returns the version of the message's input type.
+	Version() int
+	// PreviousVersions returns the older versions of the message that are still accepted. Their input is converted to
+	// the input of the latest version when it is decoded.
+	PreviousVersions() []Message
+	// IsSystemOnly reports if this message can only be sent with a system transaction signed by the system signer.
+	IsSystemOnly() bool
 
 	// GetInFieldInformation returns a map of the fields of the message's "In" type and it's field types.
 	GetInFieldInformation() map[string]any
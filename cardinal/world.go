@@ -6,6 +6,7 @@ import (
 	"errors"
 	"os"
 	"os/signal"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -49,6 +50,7 @@ type World struct {
 	SystemManager
 	MessageManager
 	QueryManager
+	EventManager
 	component.ComponentManager
 
 	namespace     Namespace
@@ -68,13 +70,32 @@ type World struct {
 	router     router.Router
 	txPool     *txpool.TxPool
 
+	// Permissions
+	// adminPersonas are the persona tags that hold the admin role without it being granted to them.
+	adminPersonas []string
+	// systemSignerAddress is the address that signs the system transactions of system-only messages.
+	systemSignerAddress string
+
 	// Receipt
 	receiptHistory *receipt.History
 	evmTxReceipts  map[string]EVMTxReceipt
+	// receiptRetention is the number of ticks worth of receipts that are persisted to storage. Receipts are only kept
+	// in memory if it is 0.
+	receiptRetention uint64
+
+	// Events
+	// eventRetention is the number of ticks worth of broadcast events that are persisted to storage.
+	eventRetention uint64
+
+	// Recovery
+	recovery *recoveryProgress
 
 	// Telemetry
 	telemetry *telemetry.Manager
 	tracer    trace.Tracer // Tracer for World
+	metrics   *telemetry.Metrics
+	// metricsEnabled is true once the metrics are served on /metrics.
+	metricsEnabled bool
 
 	// Tick
 	tick            *atomic.Uint64
@@ -84,6 +105,14 @@ type World struct {
 	tickDoneChannel chan<- uint64
 	// addChannelWaitingForNextTick accepts a channel which will be closed after a tick has been completed.
 	addChannelWaitingForNextTick chan chan struct{}
+	// tickLock is held while a tick is running, so transactions can be simulated against the state between ticks.
+	tickLock *sync.Mutex
+	// simulationLock is held while a transaction is simulated. Only one simulation runs at a time, so simulations
+	// cannot queue up on tickLock and hold off ticks.
+	simulationLock *sync.Mutex
+	// lastTick is the last completed tick, which is nil until a tick is completed. The tick and its timestamp are
+	// stored together so they are always read from the same tick.
+	lastTick *atomic.Pointer[completedTick]
 }
 
 // NewWorld creates a new World object using Redis as the storage layer
@@ -98,6 +127,10 @@ func NewWorld(opts ...WorldOption) (*World, error) {
 
 	if cfg.CardinalRollupEnabled {
 		log.Info().Msgf("Creating a new Cardinal world in rollup mode")
+		if !cfg.CardinalDebugRoutesDisabled && cfg.CardinalDebugAPIKey == "" && cfg.CardinalDebugSignerAddress == "" {
+			log.Warn().Msg("The debug routes are disabled until CARDINAL_DEBUG_API_KEY or " +
+				"CARDINAL_DEBUG_SIGNER_ADDRESS is set")
+		}
 	} else {
 		log.Warn().Msg("Cardinal is running in development mode without rollup sequencing. " +
 			"If you intended to run this for production use, set CARDINAL_ROLLUP=true")
@@ -106,7 +139,7 @@ func NewWorld(opts ...WorldOption) (*World, error) {
 	// Initialize telemetry
 	var tm *telemetry.Manager
 	if cfg.TelemetryTraceEnabled || cfg.TelemetryProfilerEnabled {
-		tm, err = telemetry.New(cfg.TelemetryTraceEnabled, cfg.TelemetryProfilerEnabled)
+		tm, err = telemetry.New(cfg.TelemetryTraceEnabled, cfg.TelemetryProfilerEnabled, cfg.telemetryOptions()...)
 		if err != nil {
 			return nil, eris.Wrap(err, "failed to create telemetry manager")
 		}
@@ -119,6 +152,8 @@ func NewWorld(opts ...WorldOption) (*World, error) {
 		DialTimeout: RedisDialTimeOut * time.Second, // Increase startup dial timeout
 	}, cfg.CardinalNamespace)
 
+	metrics := telemetry.NewMetrics(cfg.CardinalNamespace)
+
 	redisStore := gamestate.NewRedisPrimitiveStorage(redisMetaStore.Client)
 	entityCommandBuffer, err := gamestate.NewEntityCommandBuffer(&redisStore)
 	if err != nil {
@@ -137,34 +172,53 @@ func NewWorld(opts ...WorldOption) (*World, error) {
 
 		// Networking
 		server:        nil, // Will be initialized in StartGame
-		serverOptions: serverOptions,
+		serverOptions: append(cfg.debugServerOptions(), serverOptions...),
 
 		// Core modules
 		worldStage:       worldstage.NewManager(),
 		MessageManager:   newMessageManager(),
-		SystemManager:    newSystemManager(),
+		SystemManager:    newSystemManager(metrics),
 		ComponentManager: component.NewManager(&redisMetaStore),
 		QueryManager:     nil,
+		EventManager:     newEventManager(),
 		router:           nil, // Will be set if run mode is production or its injected via options
 		txPool:           txpool.New(),
 
+		// Permissions
+		adminPersonas:       nil,
+		systemSignerAddress: cfg.CardinalSystemSignerAddress,
+
 		// Receipt
-		receiptHistory: receipt.NewHistory(tick.Load(), DefaultHistoricalTicksToStore),
-		evmTxReceipts:  make(map[string]EVMTxReceipt),
+		receiptHistory:   receipt.NewHistory(tick.Load(), DefaultHistoricalTicksToStore),
+		evmTxReceipts:    make(map[string]EVMTxReceipt),
+		receiptRetention: cfg.CardinalReceiptRetentionTicks,
+
+		// Events
+		eventRetention: cfg.CardinalEventRetentionTicks,
+
+		// Recovery
+		recovery: new(recoveryProgress),
 
 		// Telemetry
 		telemetry: tm,
 		tracer:    otel.Tracer("world"),
+		metrics:   metrics,
+		// Set by enableMetrics
+		metricsEnabled: false,
 
 		// Tick
 		tick:                         tick,
 		timestamp:                    new(atomic.Uint64),
+		lastTick:                     new(atomic.Pointer[completedTick]),
 		tickResults:                  NewTickResults(tick.Load()),
 		tickChannel:                  time.Tick(time.Second), //nolint:staticcheck // its ok.
 		tickDoneChannel:              nil,                    // Will be injected via options
 		addChannelWaitingForNextTick: make(chan chan struct{}),
+		tickLock:                     &sync.Mutex{},
+		simulationLock:               &sync.Mutex{},
 	}
 	world.QueryManager = newQueryManager(world)
+	world.txPool.SetTargetTickHorizon(cfg.CardinalTargetTickHorizon)
 
 	// Initialize shard router if running in rollup mode
 	if cfg.CardinalRollupEnabled {
@@ -180,6 +234,10 @@ func NewWorld(opts ...WorldOption) (*World, error) {
 		}
 	}
 
+	if cfg.TelemetryMetricsEnabled {
+		world.enableMetrics()
+	}
+
 	// Apply options
 	for _, opt := range cardinalOptions {
 		opt(world)
@@ -203,6 +261,9 @@ func (w *World) doTick(ctx context.Context, timestamp uint64) (err error) {
 
 	startTime := time.Now()
 
+	w.tickLock.Lock()
+	defer w.tickLock.Unlock()
+
 	// The world can only perform a tick if:
 	// - We're in a recovery tick
 	// - The world is currently running
@@ -221,7 +282,21 @@ func (w *World) doTick(ctx context.Context, timestamp uint64) (err error) {
 	defer w.handleTickPanic()
 
 	// Copy the transactions from the pool so that we can safely modify the pool while the tick is running.
-	txPool := w.txPool.CopyTransactions(ctx)
+	txPool := w.txPool.CopyTransactions(ctx, w.CurrentTick())
+
+	// Tag the persisted transactions with this tick so they are not executed again if Cardinal restarts. If they
+	// cannot be tagged, the tick does not run, so the transactions are put back into the pool for the next attempt.
+	if err := w.markTransactionsForTick(txPool, w.CurrentTick()); err != nil {
+		w.txPool.Restore(txPool)
+		span.SetStatus(codes.Error, eris.ToString(err, true))
+		span.RecordError(err)
+		return eris.Wrap(err, "failed to mark transactions for tick")
+	}
+
+	// Transactions that expired while waiting in the pool are never executed, so their receipts only contain an error.
+	for _, tx := range txPool.ExpiredTransactions() {
+		w.receiptHistory.AddError(tx.TxHash, eris.Wrap(txpool.ErrTransactionExpired, ""))
+	}
 
 	// Store the timestamp for this tick
 	w.timestamp.Store(timestamp)
@@ -237,12 +312,22 @@ func (w *World) doTick(ctx context.Context, timestamp uint64) (err error) {
 		return err
 	}
 
+	w.recordSubscribedStateChanges()
+
 	if err := w.entityStore.FinalizeTick(ctx); err != nil {
 		span.SetStatus(codes.Error, eris.ToString(err, true))
 		span.RecordError(err)
 		return err
 	}
 
+	w.removePersistedTransactions(txPool)
+
+	// The events must be persisted before the tick is incremented, so that clients catching up on the events of the
+	// previous ticks don't miss them.
+	if w.worldStage.Current() != worldstage.Recovering {
+		w.persistEvents(w.CurrentTick())
+	}
+
 	w.setEvmResults(txPool.GetEVMTxs())
 
 	// Handle tx data blob submission
@@ -250,7 +335,9 @@ func (w *World) doTick(ctx context.Context, timestamp uint64) (err error) {
 	// 1. The shard router is set
 	// 2. The world is not in the recovering stage (we don't want to resubmit past transactions)
 	if w.router != nil && w.worldStage.Current() != worldstage.Recovering {
+		submitStartTime := time.Now()
 		err := w.router.SubmitTxBlob(ctx, txPool.Transactions(), w.tick.Load(), w.timestamp.Load())
+		w.metrics.ObserveRouterSubmission(time.Since(submitStartTime))
 		if err != nil {
 			span.SetStatus(codes.Error, eris.ToString(err, true))
 			span.RecordError(err)
@@ -258,15 +345,23 @@ func (w *World) doTick(ctx context.Context, timestamp uint64) (err error) {
 		}
 	}
 
+	// Record who sent each transaction and what message it contained on its receipt.
+	w.setReceiptTxInfo(txPool)
+
 	// Increment the tick
 	w.tick.Add(1)
+	w.lastTick.Store(&completedTick{tick: w.CurrentTick() - 1, timestamp: timestamp})
 	w.receiptHistory.NextTick() // todo(scott): use channels
 
+	w.persistReceipts(w.CurrentTick() - 1)
+
 	if w.worldStage.Current() != worldstage.Recovering {
 		// Populate world.TickResults for the current tick and emit it as an Event
 		w.broadcastTickResults(ctx)
 	}
 
+	w.recordTickMetrics(w.CurrentTick()-1, time.Since(startTime), txPool.GetAmountOfTxs())
+
 	log.Info().
 		Int("tick", int(w.CurrentTick()-1)).
 		Str("duration", time.Since(startTime).String()).
@@ -320,12 +415,50 @@ func (w *World) StartGame() error {
 		}
 	}
 
+	// The server is started before the world recovers, so that /health and /ready report the progress of the recovery.
+	// Every other route is rejected until the world is running.
+	var err error
+	w.server, err = server.New(w, w.GetRegisteredComponents(), w.GetRegisteredMessages(), w.serverOptions...)
+	if err != nil {
+		return err
+	}
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return w.server.Serve(ctx)
+	})
+
+	if err := w.recoverState(ctx); err != nil {
+		// Stop the server before returning.
+		cancel()
+		if serverErr := g.Wait(); serverErr != nil {
+			log.Err(serverErr).Msg("error occurred while shutting down the server")
+		}
+		return err
+	}
+
+	// World stage: Ready -> Running
+	w.worldStage.Store(worldstage.Running)
+
+	g.Go(func() error {
+		return w.startGameLoop(ctx, w.tickChannel, w.tickDoneChannel)
+	})
+	if err := g.Wait(); err != nil {
+		return eris.Wrap(err, "error occured while running cardinal")
+	}
+
+	return nil
+}
+
+// recoverState restores the tick of the world from storage, replays the transactions of the base shard if the world is
+// in rollup mode, and reloads the transactions that were accepted but not executed before Cardinal last shut down.
+func (w *World) recoverState(ctx context.Context) error {
 	w.worldStage.Store(worldstage.Recovering)
 	tick, err := w.entityStore.GetLastFinalizedTick()
 	if err != nil {
 		return eris.Wrap(err, "failed to get latest finalized tick")
 	}
 	w.tick.Store(tick)
+	w.txPool.SetTick(tick)
 
 	// If Cardinal is in rollup mode and router is set, recover any old state of Cardinal from base shard.
 	if w.rollupEnabled && w.router != nil {
@@ -334,29 +467,15 @@ func (w *World) StartGame() error {
 		}
 	}
 
+	// Reload any transactions that were accepted but not executed before Cardinal last shut down.
+	if err := w.reloadTransactions(); err != nil {
+		return eris.Wrap(err, "failed to reload pending transactions")
+	}
+
 	// TODO(scott): i find this manual tracking and incrementing of the tick very footgunny. Why can't we just
 	//  use a reliable source of truth for the tick? It's not clear to me why we need to manually increment the
 	//  receiptHistory tick separately.
 	w.receiptHistory.SetTick(w.CurrentTick())
-
-	// World stage: Ready -> Running
-	w.worldStage.Store(worldstage.Running)
-
-	g, ctx := errgroup.WithContext(ctx)
-	g.Go(func() error {
-		return w.startGameLoop(ctx, w.tickChannel, w.tickDoneChannel)
-	})
-	g.Go(func() error {
-		w.server, err = server.New(w, w.GetRegisteredComponents(), w.GetRegisteredMessages(), w.serverOptions...)
-		if err != nil {
-			return err
-		}
-		return w.server.Serve(ctx)
-	})
-	if err := g.Wait(); err != nil {
-		return eris.Wrap(err, "error occured while running cardinal")
-	}
-
 	return nil
 }
 
@@ -486,11 +605,37 @@ func (w *World) drainChannelsWaitingForNextTick() {
 func (w *World) AddTransaction(id types.MessageID, v any, sig *sign.Transaction) (
 	tick uint64, txHash types.TxHash,
 ) {
-	// TODO: There's no locking between getting the tick and adding the transaction, so there's no guarantee that this
-	// transaction is actually added to the returned tick.
-	tick = w.CurrentTick()
-	txHash = w.txPool.AddTransaction(id, v, sig)
-	return tick, txHash
+	w.persistTransactions(txpool.TxData{MsgID: id, Msg: v, Tx: sig})
+	return w.txPool.AddTransaction(id, v, sig)
+}
+
+// ValidateTransaction checks that the persona that signed the transaction is allowed to send the given message, then
+// runs the validators registered for the message against the message and its transaction. A nil error means the
+// transaction can be added to the transaction pool. The signature of the transaction must already have been verified,
+// so that validators never run for transactions sent on behalf of someone else.
+func (w *World) ValidateTransaction(id types.MessageID, msg any, sig *sign.Transaction) error {
+	msgType, ok := w.GetMessageByID(id)
+	if !ok {
+		return eris.Errorf("message with id %d not found", id)
+	}
+	if err := w.txPool.ValidateTicks(sig); err != nil {
+		return err
+	}
+	if err := w.authorizeTransaction(msgType, sig); err != nil {
+		return err
+	}
+	validatable, ok := msgType.(validatableMessage)
+	if !ok {
+		return nil
+	}
+	return validatable.validate(NewReadOnlyWorldContext(w), msg, sig)
+}
+
+// AddTransactions adds all the given transactions to the pool at once so that they are all executed in the same
+// tick. The transactions are expected to share the same target tick.
+func (w *World) AddTransactions(txs []txpool.TxData) (tick uint64, txHashes []types.TxHash) {
+	w.persistTransactions(txs...)
+	return w.txPool.AddTransactions(txs)
 }
 
 func (w *World) AddEVMTransaction(
@@ -501,15 +646,24 @@ func (w *World) AddEVMTransaction(
 ) (
 	tick uint64, txHash types.TxHash,
 ) {
-	tick = w.CurrentTick()
-	txHash = w.txPool.AddEVMTransaction(id, v, sig, evmTxHash)
-	return tick, txHash
+	return w.txPool.AddEVMTransaction(id, v, sig, evmTxHash)
+}
+
+// SystemSignerAddress returns the address that signs the system transactions of system-only messages. It is empty if
+// no system signer has been set.
+func (w *World) SystemSignerAddress() string {
+	return w.systemSignerAddress
 }
 
 func (w *World) UseNonce(signerAddress string, nonce uint64) error {
 	return w.redisStorage.UseNonce(signerAddress, nonce)
 }
 
+// UseNonces marks all the given nonces as used. If any of the nonces cannot be used, none of them are marked as used.
+func (w *World) UseNonces(signerAddress string, nonces []uint64) error {
+	return w.redisStorage.UseNonces(signerAddress, nonces)
+}
+
 func (w *World) GetDebugState() ([]types.DebugStateElement, error) {
 	result := make([]types.DebugStateElement, 0)
 	s := w.Search(filter.All())
@@ -558,10 +712,24 @@ func (w *World) GameStateManager() gamestate.Manager {
 // WaitForNextTick blocks until at least one game tick has completed. It returns true if it successfully waited for a
 // tick. False may be returned if the engine was shut down while waiting for the next tick to complete.
 func (w *World) WaitForNextTick() (success bool) {
+	return w.WaitForNextTickContext(context.Background())
+}
+
+// WaitForNextTickContext is like WaitForNextTick, but gives up and returns false once ctx is done. The game loop only
+// accepts waiters while it is running, so callers that cannot rely on it running should use this instead.
+func (w *World) WaitForNextTickContext(ctx context.Context) bool {
 	startTick := w.CurrentTick()
 	ch := make(chan struct{})
-	w.addChannelWaitingForNextTick <- ch
-	<-ch
+	select {
+	case w.addChannelWaitingForNextTick <- ch:
+	case <-ctx.Done():
+		return false
+	}
+	select {
+	case <-ch:
+	case <-ctx.Done():
+		return false
+	}
 	return w.CurrentTick() > startTick
 }
 
@@ -586,6 +754,49 @@ func (w *World) GetMessageByID(id types.MessageID) (types.Message, bool) {
 	return msg, msg != nil
 }
 
+// enableMetrics serves the metrics of the world on /metrics, and starts recording the duration of Redis commands. The
+// other metrics are always recorded, as they are cheap to record.
+func (w *World) enableMetrics() {
+	if w.metricsEnabled {
+		return
+	}
+	w.metricsEnabled = true
+	w.redisStorage.Client.AddHook(w.metrics.RedisHook())
+	w.serverOptions = append(w.serverOptions, server.WithMetrics(w.metrics))
+}
+
+// recordTickMetrics records the duration of a finished tick, and the transactions it executed by message.
+func (w *World) recordTickMetrics(tick uint64, duration time.Duration, txCount int) {
+	w.metrics.ObserveTick(duration, txCount)
+	receipts, err := w.receiptHistory.GetReceiptsForTick(tick)
+	if err != nil {
+		log.Err(err).Uint64("tick", tick).Msg("failed to get receipts to record metrics")
+		return
+	}
+	for _, rec := range receipts {
+		w.metrics.ObserveReceipt(rec.MsgName, len(rec.Errs) > 0)
+	}
+}
+
+// recordSubscribedStateChanges adds the pending changes to the entities that websocket connections subscribed to to
+// the tick results. It must be called before the tick is finalized, as the pending changes are discarded afterward.
+// Subscriptions are best effort, so an error is logged instead of failing the tick.
+func (w *World) recordSubscribedStateChanges() {
+	if w.server == nil || w.worldStage.Current() == worldstage.Recovering {
+		return
+	}
+	ids := w.server.SubscribedEntities()
+	if len(ids) == 0 {
+		return
+	}
+	changes, err := w.entityStore.PendingStateChanges(ids...)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get state changes of subscribed entities")
+		return
+	}
+	w.tickResults.SetStateChanges(changes)
+}
+
 func (w *World) broadcastTickResults(ctx context.Context) {
 	_, span := w.tracer.Start(ddotel.ContextWithStartOptions(ctx, ddtracer.Measured()),
 		"world.tick.broadcast_tick_results")
@@ -607,12 +818,18 @@ func (w *World) broadcastTickResults(ctx context.Context) {
 		log.Err(err).Msgf("failed to broadcast tick results")
 	}
 
+	// Send the private events of the tick to the subscribers of each persona
+	for personaTag, events := range w.tickResults.PrivateEvents() {
+		w.server.SendPrivateEvents(personaTag, w.CurrentTick()-1, events)
+	}
+
 	// Clear the TickResults for this tick in preparation for the next tick
 	w.tickResults.Clear()
 }
 
+// ReceiptHistorySize returns the number of ticks worth of receipts that are available, either in memory or in storage.
 func (w *World) ReceiptHistorySize() uint64 {
-	return w.receiptHistory.Size()
+	return max(w.receiptHistory.Size(), w.receiptRetention)
 }
 
 func (w *World) EvaluateCQL(cqlString string) ([]types.EntityStateElement, error) {
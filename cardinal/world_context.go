@@ -43,10 +43,13 @@ type WorldContext interface {
 
 	// SetLogger is used to inject a new logger configuration to an engine context that is already created.
 	setLogger(logger zerolog.Logger)
+	emitPrivateEvent(personaTag string, event map[string]any) error
 	addMessageError(id types.TxHash, err error)
 	setMessageResult(id types.TxHash, a any)
 	getComponentByName(name string) (types.ComponentMetadata, error)
 	getMessageByType(mType reflect.Type) (types.Message, bool)
+	getEventNameByType(eventType reflect.Type) (string, bool)
+	getMessageMiddleware(group string) []MessageMiddleware
 	getTransactionReceipt(id types.TxHash) (any, []error, bool)
 	getSignerForPersonaTag(personaTag string, tick uint64) (addr string, err error)
 	getTransactionReceiptsForTick(tick uint64) ([]receipt.Receipt, error)
@@ -57,6 +60,10 @@ type WorldContext interface {
 	storeManager() gamestate.Manager
 	getTxPool() *txpool.TxPool
 	isReadOnly() bool
+	// isSimulation returns true if the context belongs to a simulated transaction, whose results are discarded.
+	isSimulation() bool
+	// personaIndex returns the persona index the persona systems read and update.
+	personaIndex() personaIndex
 }
 
 type worldContext struct {
@@ -124,6 +131,14 @@ func (ctx *worldContext) getMessageByType(mType reflect.Type) (types.Message, bo
 	return ctx.world.GetMessageByType(mType)
 }
 
+func (ctx *worldContext) getEventNameByType(eventType reflect.Type) (string, bool) {
+	return ctx.world.GetEventNameByType(eventType)
+}
+
+func (ctx *worldContext) getMessageMiddleware(group string) []MessageMiddleware {
+	return ctx.world.GetMessageMiddleware(group)
+}
+
 func (ctx *worldContext) setLogger(logger zerolog.Logger) {
 	ctx.logger = &logger
 }
@@ -158,6 +173,10 @@ func (ctx *worldContext) EmitStringEvent(e string) error {
 	return ctx.world.tickResults.AddStringEvent(e)
 }
 
+func (ctx *worldContext) emitPrivateEvent(personaTag string, event map[string]any) error {
+	return ctx.world.tickResults.AddPrivateEvent(personaTag, event)
+}
+
 func (ctx *worldContext) getSignerForPersonaTag(personaTag string, tick uint64) (addr string, err error) {
 	return ctx.world.GetSignerForPersonaTag(personaTag, tick)
 }
@@ -186,6 +205,14 @@ func (ctx *worldContext) isReadOnly() bool {
 	return ctx.readOnly
 }
 
+func (ctx *worldContext) isSimulation() bool {
+	return false
+}
+
+func (ctx *worldContext) personaIndex() personaIndex {
+	return globalPersonaTagToAddressIndex
+}
+
 func (ctx *worldContext) storeManager() gamestate.Manager {
 	return ctx.world.entityStore
 }
@@ -0,0 +1,64 @@
+package cardinal
+
+import (
+	"github.com/rs/zerolog/log"
+
+	"pkg.world.dev/world-engine/cardinal/codec"
+)
+
+// GetEventsForTicks returns the events that were broadcast in the ticks in the range [startTick, endTick), keyed by
+// tick. Ticks without events or whose events are no longer retained are omitted.
+func (w *World) GetEventsForTicks(startTick, endTick uint64) (map[uint64][][]byte, error) {
+	for startTick < endTick && !w.isEventRetained(startTick) {
+		startTick++
+	}
+	result := map[uint64][][]byte{}
+	if startTick == endTick {
+		return result, nil
+	}
+	stored, err := w.redisStorage.GetEventsForTicks(startTick, endTick)
+	if err != nil {
+		return nil, err
+	}
+	for tick, bz := range stored {
+		events, err := codec.Decode[[][]byte](bz)
+		if err != nil {
+			return nil, err
+		}
+		result[tick] = events
+	}
+	return result, nil
+}
+
+// EventHistorySize returns the number of ticks worth of events that are kept in storage.
+func (w *World) EventHistorySize() uint64 {
+	return w.eventRetention
+}
+
+// isEventRetained reports whether the events of the given tick are kept in storage.
+func (w *World) isEventRetained(tick uint64) bool {
+	return w.eventRetention > 0 && w.CurrentTick()-tick <= w.eventRetention
+}
+
+// persistEvents stores the events of the given tick, and removes the events of ticks that are no longer retained. The
+// tick has already been finalized at this point, so errors are only logged.
+func (w *World) persistEvents(tick uint64) {
+	if w.eventRetention == 0 {
+		return
+	}
+	if len(w.tickResults.Events) > 0 {
+		bz, err := codec.Encode(w.tickResults.Events)
+		if err != nil {
+			log.Err(err).Uint64("tick", tick).Msg("failed to encode events")
+			return
+		}
+		if err := w.redisStorage.SetEvents(tick, bz); err != nil {
+			log.Err(err).Uint64("tick", tick).Msg("failed to persist events")
+		}
+	}
+	if tick >= w.eventRetention {
+		if err := w.redisStorage.DeleteEventsBefore(tick - w.eventRetention + 1); err != nil {
+			log.Err(err).Uint64("tick", tick).Msg("failed to remove old events")
+		}
+	}
+}
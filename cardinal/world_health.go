@@ -0,0 +1,57 @@
+package cardinal
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"pkg.world.dev/world-engine/cardinal/types"
+)
+
+// healthCheckTimeout is how long Redis has to respond to a health check.
+const healthCheckTimeout = time.Second
+
+// completedTick is a tick that has been completed and the timestamp it ran with.
+type completedTick struct {
+	tick      uint64
+	timestamp uint64
+}
+
+// Health returns the stage of the world, its last completed tick, the connectivity of Redis and the base shard, and
+// the progress of its recovery from the base shard.
+func (w *World) Health(ctx context.Context) types.HealthStatus {
+	status := types.HealthStatus{
+		Stage:    string(w.worldStage.Current()),
+		LastTick: nil,
+		Redis:    types.ServiceStatus{Connected: true, State: "", Error: ""},
+		Router:   nil,
+		Recovery: w.recovery.progress(w.CurrentTick()),
+	}
+
+	if last := w.lastTick.Load(); last != nil {
+		status.LastTick = &types.TickStatus{
+			Tick:      last.tick,
+			Timestamp: last.timestamp,
+			AgeMs:     time.Now().UnixMilli() - int64(last.timestamp),
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	if err := w.redisStorage.Client.Ping(ctx).Err(); err != nil {
+		status.Redis = types.ServiceStatus{Connected: false, State: "", Error: err.Error()}
+	}
+
+	if w.router != nil {
+		state := w.router.ConnectionState()
+		status.Router = &types.ServiceStatus{
+			// The connection is idle until it is used, and reconnects on its own while it is connecting.
+			Connected: state != connectivity.TransientFailure && state != connectivity.Shutdown,
+			State:     state.String(),
+			Error:     "",
+		}
+	}
+
+	return status
+}
@@ -2,6 +2,7 @@ package cardinal
 
 import (
 	"errors"
+	"slices"
 
 	"github.com/rotisserie/eris"
 
@@ -9,8 +10,14 @@ import (
 	"pkg.world.dev/world-engine/cardinal/persona"
 	"pkg.world.dev/world-engine/cardinal/persona/component"
 	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
 )
 
+// authorizedMessage is implemented by messages that can only be sent by personas with certain roles.
+type authorizedMessage interface {
+	requiredRoles() []string
+}
+
 // GetSignerForPersonaTag returns the signer address that has been registered for the given persona tag after the
 // given tick. If the engine's tick is less than or equal to the given tick, ErrorCreatePersonaTXsNotProcessed is
 // returned. If the given personaTag has no signer address, ErrPersonaTagHasNoSigner is returned.
@@ -20,7 +27,7 @@ func (w *World) GetSignerForPersonaTag(personaTag string, tick uint64) (addr str
 	}
 	var errs []error
 	wCtx := NewReadOnlyWorldContext(w)
-	s := NewSearch().Entity(filter.Exact(filter.Component[component.SignerComponent]()))
+	s := NewSearch().Entity(filter.Contains(filter.Component[component.SignerComponent]()))
 	err = s.Each(wCtx,
 		func(id types.EntityID) bool {
 			sc, err := GetComponent[component.SignerComponent](wCtx, id)
@@ -44,7 +51,7 @@ func (w *World) GetSignerForPersonaTag(personaTag string, tick uint64) (addr str
 func (w *World) GetSignerComponentForPersona(personaTag string) (*component.SignerComponent, error) {
 	var sc *component.SignerComponent
 	wCtx := NewReadOnlyWorldContext(w)
-	q := NewSearch().Entity(filter.Exact(filter.Component[component.SignerComponent]()))
+	q := NewSearch().Entity(filter.Contains(filter.Component[component.SignerComponent]()))
 	var getComponentErr error
 	searchIterationErr := eris.Wrap(
 		q.Each(wCtx,
@@ -73,3 +80,66 @@ func (w *World) GetSignerComponentForPersona(personaTag string) (*component.Sign
 	}
 	return sc, nil
 }
+
+// GetPersonaRoles returns the roles held by the given persona tag. A persona tag that does not exist holds no roles,
+// unless it was given the admin role with WithAdminPersonas.
+func (w *World) GetPersonaRoles(personaTag string) ([]string, error) {
+	var roles []string
+	if slices.Contains(w.adminPersonas, personaTag) {
+		roles = append(roles, persona.AdminRole)
+	}
+	wCtx := NewReadOnlyWorldContext(w)
+	// Only the personas that have been granted a role have a RoleComponent.
+	q := NewSearch().Entity(filter.Contains(
+		filter.Component[component.SignerComponent](),
+		filter.Component[component.RoleComponent](),
+	))
+	var getComponentErr error
+	searchIterationErr := eris.Wrap(
+		q.Each(wCtx,
+			func(id types.EntityID) bool {
+				var signerComp *component.SignerComponent
+				signerComp, getComponentErr = GetComponent[component.SignerComponent](wCtx, id)
+				if getComponentErr != nil {
+					return false
+				}
+				if signerComp.PersonaTag != personaTag {
+					return true
+				}
+				var roleComp *component.RoleComponent
+				roleComp, getComponentErr = GetComponent[component.RoleComponent](wCtx, id)
+				if getComponentErr == nil {
+					roles = append(roles, roleComp.Roles...)
+				}
+				return false
+			},
+		), "",
+	)
+	if getComponentErr != nil {
+		return nil, getComponentErr
+	}
+	if searchIterationErr != nil {
+		return nil, searchIterationErr
+	}
+	return roles, nil
+}
+
+// authorizeTransaction checks that the persona that signed the transaction holds at least one of the roles required
+// by the given message. Messages that do not require any role can be sent by any persona.
+func (w *World) authorizeTransaction(msgType types.Message, tx *sign.Transaction) error {
+	authorized, ok := msgType.(authorizedMessage)
+	if !ok || len(authorized.requiredRoles()) == 0 {
+		return nil
+	}
+	roles, err := w.GetPersonaRoles(tx.PersonaTag)
+	if err != nil {
+		return err
+	}
+	for _, role := range authorized.requiredRoles() {
+		if slices.Contains(roles, role) {
+			return nil
+		}
+	}
+	return eris.Wrapf(persona.ErrPersonaNotAuthorized, "persona %q does not have any of the roles %v required by %q",
+		tx.PersonaTag, authorized.requiredRoles(), msgType.FullName())
+}
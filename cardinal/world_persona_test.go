@@ -9,6 +9,7 @@ import (
 	"pkg.world.dev/world-engine/assert"
 	"pkg.world.dev/world-engine/cardinal"
 	"pkg.world.dev/world-engine/cardinal/filter"
+	"pkg.world.dev/world-engine/cardinal/persona"
 	"pkg.world.dev/world-engine/cardinal/persona/component"
 	"pkg.world.dev/world-engine/cardinal/persona/msg"
 	"pkg.world.dev/world-engine/cardinal/types"
@@ -160,3 +161,61 @@ func TestCreatePersonaSystem_WhenCardinalIsRestarted_PersonaTagsAreStillRegister
 	assert.Len(t, errs, 1)
 	assert.ErrorContains(t, errs[0], "persona tag pt5 has already been registered")
 }
+
+func TestPersonaRolesCanBeGrantedAndRevoked(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil, cardinal.WithAdminPersonas("gamemaster"))
+	world := tf.World
+	assert.NilError(t, cardinal.RegisterMessage[fooMessage, fooResponse](world, "spawn",
+		cardinal.WithMsgRequiredRoles[fooMessage, fooResponse]("moderator", "designer")))
+	tf.CreatePersona("gamemaster", "admin-signer")
+	tf.CreatePersona("alice", "alice-signer")
+
+	spawnMsg, ok := world.GetMessageByFullName("game.spawn")
+	assert.True(t, ok)
+	grantMsg, ok := world.GetMessageByFullName("persona." + msg.GrantRoleMessageName)
+	assert.True(t, ok)
+	revokeMsg, ok := world.GetMessageByFullName("persona." + msg.RevokeRoleMessageName)
+	assert.True(t, ok)
+
+	aliceTx := &sign.Transaction{PersonaTag: "alice"}
+	adminTx := &sign.Transaction{PersonaTag: "gamemaster"}
+	err := world.ValidateTransaction(spawnMsg.ID(), fooMessage{}, aliceTx)
+	assert.ErrorIs(t, err, persona.ErrPersonaNotAuthorized)
+	grant := msg.GrantRole{PersonaTag: "alice", Role: "designer"}
+	err = world.ValidateTransaction(grantMsg.ID(), grant, aliceTx)
+	assert.ErrorIs(t, err, persona.ErrPersonaNotAuthorized)
+	assert.NilError(t, world.ValidateTransaction(grantMsg.ID(), grant, adminTx))
+
+	tf.AddTransaction(grantMsg.ID(), grant, adminTx)
+	tf.DoTick()
+	roles, err := world.GetPersonaRoles("alice")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"designer"}, roles)
+	// Holding any one of the required roles is enough.
+	assert.NilError(t, world.ValidateTransaction(spawnMsg.ID(), fooMessage{}, aliceTx))
+	// Personas with roles are still found by their signer component.
+	sc, err := world.GetSignerComponentForPersona("alice")
+	assert.NilError(t, err)
+	assert.Equal(t, "alice-signer", sc.SignerAddress)
+
+	tf.AddTransaction(revokeMsg.ID(), msg.RevokeRole{PersonaTag: "alice", Role: "designer"}, adminTx)
+	tf.DoTick()
+	roles, err = world.GetPersonaRoles("alice")
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(roles))
+	err = world.ValidateTransaction(spawnMsg.ID(), fooMessage{}, aliceTx)
+	assert.ErrorIs(t, err, persona.ErrPersonaNotAuthorized)
+}
+
+func TestSystemPersonaTagIsReserved(t *testing.T) {
+	tf := cardinal.NewTestFixture(t, nil)
+	tf.CreatePersona(sign.SystemPersonaTag, "some-signer")
+
+	receipts, err := tf.World.GetTransactionReceiptsForTick(tf.World.CurrentTick() - 1)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(receipts))
+	assert.Equal(t, 1, len(receipts[0].Errs))
+	assert.ErrorContains(t, receipts[0].Errs[0], "is reserved")
+	_, err = tf.World.GetSignerForPersonaTag(sign.SystemPersonaTag, 0)
+	assert.ErrorIs(t, err, persona.ErrPersonaTagHasNoSigner)
+}
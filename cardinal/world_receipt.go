@@ -1,20 +1,199 @@
 package cardinal
 
 import (
+	"encoding/json"
+	"errors"
+
 	"github.com/rotisserie/eris"
+	"github.com/rs/zerolog/log"
 
+	"pkg.world.dev/world-engine/cardinal/codec"
 	"pkg.world.dev/world-engine/cardinal/receipt"
+	"pkg.world.dev/world-engine/cardinal/storage/redis"
 	"pkg.world.dev/world-engine/cardinal/txpool"
+	"pkg.world.dev/world-engine/cardinal/types"
 )
 
+// persistedReceipt is the form in which a receipt is stored. It matches the JSON encoding of receipt.Receipt.
+type persistedReceipt struct {
+	TxHash     types.TxHash    `json:"txHash"`
+	PersonaTag string          `json:"personaTag"`
+	MsgName    string          `json:"msgName"`
+	Result     json.RawMessage `json:"result"`
+	Errs       []string        `json:"errors"`
+}
+
 type EVMTxReceipt struct {
 	ABIResult []byte
 	Errs      []error
 	EVMTxHash string
 }
 
+// GetTransactionReceiptsForTick returns the receipts for the given tick. Receipts for ticks that are no longer kept in
+// memory are loaded from storage.
 func (w *World) GetTransactionReceiptsForTick(tick uint64) ([]receipt.Receipt, error) {
-	return w.receiptHistory.GetReceiptsForTick(tick)
+	receipts, err := w.receiptHistory.GetReceiptsForTick(tick)
+	if !errors.Is(err, receipt.ErrOldTickHasBeenDiscarded) || !w.isReceiptRetained(tick) {
+		return receipts, err
+	}
+	stored, err := w.loadReceipts(tick, tick+1)
+	if err != nil {
+		return nil, err
+	}
+	return stored[tick], nil
+}
+
+// GetTransactionReceiptsForTicks returns the receipts for the ticks in the range [startTick, endTick), keyed by tick.
+// Ticks that have not been processed yet or whose receipts have been discarded are omitted.
+func (w *World) GetTransactionReceiptsForTicks(startTick, endTick uint64) (map[uint64][]receipt.Receipt, error) {
+	result := map[uint64][]receipt.Receipt{}
+	// Receipts for ticks up to storedEndTick are no longer kept in memory, so they are loaded from storage in one go.
+	storedEndTick := startTick
+	for tick := startTick; tick < endTick; tick++ {
+		receipts, err := w.receiptHistory.GetReceiptsForTick(tick)
+		if errors.Is(err, receipt.ErrOldTickHasBeenDiscarded) {
+			storedEndTick = tick + 1
+			continue
+		} else if err != nil {
+			continue
+		}
+		result[tick] = receipts
+	}
+	storedStartTick := startTick
+	for storedStartTick < storedEndTick && !w.isReceiptRetained(storedStartTick) {
+		storedStartTick++
+	}
+	if storedStartTick == storedEndTick {
+		return result, nil
+	}
+	stored, err := w.loadReceipts(storedStartTick, storedEndTick)
+	if err != nil {
+		return nil, err
+	}
+	for tick, receipts := range stored {
+		result[tick] = receipts
+	}
+	return result, nil
+}
+
+// GetTransactionReceipt returns the receipt for the given transaction hash, along with the tick it was produced in.
+// Receipts are only available once the tick they were produced in has been processed. If no receipt is found,
+// receipt.ErrReceiptNotFound is returned.
+func (w *World) GetTransactionReceipt(txHash types.TxHash) (receipt.Receipt, uint64, error) {
+	if rec, tick, ok := w.receiptHistory.FindReceipt(txHash); ok {
+		return rec, tick, nil
+	}
+	if w.receiptRetention == 0 {
+		return receipt.Receipt{}, 0, eris.Wrap(receipt.ErrReceiptNotFound, "")
+	}
+	bz, tick, err := w.redisStorage.GetReceipt(string(txHash))
+	if errors.Is(err, redis.ErrNoReceiptFound) {
+		return receipt.Receipt{}, 0, eris.Wrap(receipt.ErrReceiptNotFound, "")
+	} else if err != nil {
+		return receipt.Receipt{}, 0, err
+	}
+	rec, err := decodePersistedReceipt(bz)
+	if err != nil {
+		return receipt.Receipt{}, 0, err
+	}
+	return rec, tick, nil
+}
+
+// isReceiptRetained reports whether the receipts for the given tick are kept in storage.
+func (w *World) isReceiptRetained(tick uint64) bool {
+	return w.receiptRetention > 0 && w.CurrentTick()-tick <= w.receiptRetention
+}
+
+// persistReceipts stores the receipts of the given tick, and removes the receipts of ticks that are no longer
+// retained. The tick has already been finalized at this point, so errors are only logged.
+func (w *World) persistReceipts(tick uint64) {
+	if w.receiptRetention == 0 {
+		return
+	}
+	receipts, err := w.receiptHistory.GetReceiptsForTick(tick)
+	if err != nil {
+		log.Err(err).Uint64("tick", tick).Msg("failed to get receipts to persist")
+		return
+	}
+	toStore := make(map[string][]byte, len(receipts))
+	for _, rec := range receipts {
+		bz, err := codec.Encode(rec)
+		if err != nil {
+			log.Err(err).Str("tx_hash", string(rec.TxHash)).Msg("failed to encode receipt")
+			continue
+		}
+		toStore[string(rec.TxHash)] = bz
+	}
+	if err := w.redisStorage.SetReceipts(tick, toStore); err != nil {
+		log.Err(err).Uint64("tick", tick).Msg("failed to persist receipts")
+	}
+	if tick >= w.receiptRetention {
+		if err := w.redisStorage.DeleteReceiptsBefore(tick - w.receiptRetention + 1); err != nil {
+			log.Err(err).Uint64("tick", tick).Msg("failed to remove old receipts")
+		}
+	}
+}
+
+func (w *World) loadReceipts(startTick, endTick uint64) (map[uint64][]receipt.Receipt, error) {
+	stored, err := w.redisStorage.GetReceiptsForTicks(startTick, endTick)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[uint64][]receipt.Receipt, len(stored))
+	for tick, receipts := range stored {
+		for _, bz := range receipts {
+			rec, err := decodePersistedReceipt(bz)
+			if err != nil {
+				return nil, err
+			}
+			result[tick] = append(result[tick], rec)
+		}
+	}
+	return result, nil
+}
+
+func decodePersistedReceipt(bz []byte) (receipt.Receipt, error) {
+	prec, err := codec.Decode[persistedReceipt](bz)
+	if err != nil {
+		return receipt.Receipt{}, err
+	}
+	rec := receipt.Receipt{
+		TxHash:     prec.TxHash,
+		PersonaTag: prec.PersonaTag,
+		MsgName:    prec.MsgName,
+	}
+	if len(prec.Result) > 0 && string(prec.Result) != "null" {
+		rec.Result = prec.Result
+	}
+	for _, errStr := range prec.Errs {
+		rec.Errs = append(rec.Errs, errors.New(errStr))
+	}
+	return rec, nil
+}
+
+// setReceiptTxInfo records the persona tag and message name of each transaction in the given copy of the pool on its
+// receipt.
+func (w *World) setReceiptTxInfo(txPool *txpool.TxPool) {
+	setTxInfo := func(tx txpool.TxData) {
+		msgName := ""
+		if msg, ok := w.GetMessageByID(tx.MsgID); ok {
+			msgName = msg.FullName()
+		}
+		w.receiptHistory.SetTxInfo(tx.TxHash, tx.Tx.PersonaTag, msgName)
+	}
+	for _, txs := range txPool.Transactions() {
+		for _, tx := range txs {
+			setTxInfo(tx)
+		}
+	}
+	for _, tx := range txPool.ExpiredTransactions() {
+		setTxInfo(tx)
+	}
+}
+
+// GetPendingTransactions returns the transactions that are being held in the pool until their target tick.
+func (w *World) GetPendingTransactions() []txpool.TxData {
+	return w.txPool.PendingTransactions()
 }
 
 // ConsumeEVMMsgResult consumes a tx result from an EVM originated Cardinal message.
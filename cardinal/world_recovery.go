@@ -2,13 +2,36 @@ package cardinal
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/rotisserie/eris"
 	"github.com/rs/zerolog/log"
 
 	"pkg.world.dev/world-engine/cardinal/router/iterator"
+	"pkg.world.dev/world-engine/cardinal/types"
 )
 
+// recoveryProgress tracks the recovery of the world from the base shard, so that it can be reported by /health.
+type recoveryProgress struct {
+	started       atomic.Bool
+	complete      atomic.Bool
+	startTick     atomic.Uint64
+	replayedTicks atomic.Uint64
+}
+
+// progress returns the progress of the recovery, or nil if the world did not recover from the base shard.
+func (r *recoveryProgress) progress(currentTick uint64) *types.RecoveryProgress {
+	if !r.started.Load() {
+		return nil
+	}
+	return &types.RecoveryProgress{
+		StartTick:     r.startTick.Load(),
+		CurrentTick:   currentTick,
+		ReplayedTicks: r.replayedTicks.Load(),
+		Complete:      r.complete.Load(),
+	}
+}
+
 // recoverFromChain will attempt to recover the state of the engine based on historical transaction data.
 // The function puts the World in a recovery state, and will then query all transaction batches under the World's
 // namespace. The function will continuously ask the EVM base shard for batches, and run ticks for each batch returned.
@@ -23,6 +46,8 @@ func (w *World) recoverFromChain(ctx context.Context) error {
 	log.Info().Msgf("Synchronizing state from base shard starting from tick %d", w.CurrentTick())
 
 	start := w.CurrentTick()
+	w.recovery.startTick.Store(start)
+	w.recovery.started.Store(true)
 	err := w.router.TransactionIterator().Each(func(batches []*iterator.TxBatch, tick, timestamp uint64) error {
 		select {
 		case <-ctx.Done():
@@ -49,6 +74,7 @@ func (w *World) recoverFromChain(ctx context.Context) error {
 			if err := w.doTick(context.Background(), timestamp); err != nil {
 				return eris.Wrap(err, "failed to tick world")
 			}
+			w.recovery.replayedTicks.Add(1)
 			return nil
 		}
 	}, start)
@@ -56,6 +82,7 @@ func (w *World) recoverFromChain(ctx context.Context) error {
 		return eris.Wrap(err, "encountered an error while recovering from chain")
 	}
 
+	w.recovery.complete.Store(true)
 	log.Info().Msgf("Successfully synchronized state from base shard")
 	return nil
 }
@@ -1,16 +1,23 @@
 package cardinal_test
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/connectivity"
 
 	"pkg.world.dev/world-engine/assert"
 	"pkg.world.dev/world-engine/cardinal"
 	"pkg.world.dev/world-engine/cardinal/router/iterator"
 	iteratormocks "pkg.world.dev/world-engine/cardinal/router/iterator/mocks"
 	"pkg.world.dev/world-engine/cardinal/router/mocks"
+	"pkg.world.dev/world-engine/cardinal/server/handler"
 	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/cardinal/worldstage"
 	"pkg.world.dev/world-engine/sign"
 )
 
@@ -91,3 +98,105 @@ func TestWorldRecovery(t *testing.T) {
 
 	controller.Finish()
 }
+
+func TestRoutesAreUnavailableWhileRecovering(t *testing.T) {
+	setEnvToCardinalRollupMode(t)
+
+	controller := gomock.NewController(t)
+	router := mocks.NewMockRouter(controller)
+	tf := cardinal.NewTestFixture(t, nil, cardinal.WithCustomRouter(router))
+	world := tf.World
+	assert.NilError(t, cardinal.RegisterMessage[fooMessage, fooResponse](world, "foo"))
+	fooTx, ok := world.GetMessageByFullName("game.foo")
+	assert.Check(t, ok)
+
+	timestamp := uint64(time.Now().UnixMilli())
+	var healthStatus, readyStatus, txStatus int
+	var health handler.GetHealthResponse
+
+	// The routes are requested while the world is replaying the transactions of the base shard.
+	iter := iteratormocks.NewMockIterator(controller)
+	iter.EXPECT().Each(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(fn func(batch []*iterator.TxBatch, tick, timestamp uint64) error, _ ...uint64) error {
+			var err error
+			if healthStatus, health, err = getHealth(tf.BaseURL + "/health"); err != nil {
+				return err
+			}
+			if readyStatus, _, err = getHealth(tf.BaseURL + "/ready"); err != nil {
+				return err
+			}
+			if txStatus, err = postEmptyTransaction(tf.BaseURL + "/tx/game/foo"); err != nil {
+				return err
+			}
+			batch := []*iterator.TxBatch{{
+				Tx:       &sign.Transaction{PersonaTag: "ty"},
+				MsgID:    fooTx.ID(),
+				MsgValue: fooMessage{Bar: "hello"},
+			}}
+			return fn(batch, 0, timestamp)
+		})
+	router.EXPECT().TransactionIterator().Return(iter).Times(1)
+	router.EXPECT().Start().Times(1)
+	router.EXPECT().RegisterGameShard(gomock.Any()).Times(1)
+	router.EXPECT().ConnectionState().Return(connectivity.Ready).AnyTimes()
+	router.EXPECT().SubmitTxBlob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	tf.StartWorld()
+
+	assert.Equal(t, http.StatusOK, healthStatus)
+	assert.Equal(t, http.StatusServiceUnavailable, readyStatus)
+	assert.Equal(t, http.StatusServiceUnavailable, txStatus)
+	assert.Equal(t, string(worldstage.Recovering), health.Stage)
+	assert.Check(t, !health.IsReady)
+	assert.Check(t, health.Redis.Connected)
+	assert.Check(t, health.Router.Connected)
+	assert.DeepEqual(t, &types.RecoveryProgress{}, health.Recovery)
+
+	// Once the world is running, it reports the tick it recovered.
+	status := world.Health(context.Background())
+	assert.Equal(t, string(worldstage.Running), status.Stage)
+	assert.Equal(t, uint64(0), status.LastTick.Tick)
+	assert.Equal(t, timestamp, status.LastTick.Timestamp)
+	assert.DeepEqual(t, &types.RecoveryProgress{CurrentTick: 1, ReplayedTicks: 1, Complete: true}, status.Recovery)
+
+	readyStatus, _, err := getHealth(tf.BaseURL + "/ready")
+	assert.NilError(t, err)
+	assert.Equal(t, http.StatusOK, readyStatus)
+
+	controller.Finish()
+}
+
+// getHealth gets /health or /ready, retrying until the server is listening.
+func getHealth(url string) (int, handler.GetHealthResponse, error) {
+	var body handler.GetHealthResponse
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "http://"+url, nil)
+	if err != nil {
+		return 0, body, err
+	}
+	var res *http.Response
+	for i := 0; i < 100; i++ {
+		if res, err = http.DefaultClient.Do(req); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		return 0, body, err
+	}
+	defer res.Body.Close()
+	err = json.NewDecoder(res.Body).Decode(&body)
+	return res.StatusCode, body, err
+}
+
+func postEmptyTransaction(url string) (int, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "http://"+url, nil)
+	if err != nil {
+		return 0, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	return res.StatusCode, nil
+}
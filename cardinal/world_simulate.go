@@ -0,0 +1,165 @@
+package cardinal
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	"github.com/rotisserie/eris"
+
+	"pkg.world.dev/world-engine/cardinal/gamestate"
+	"pkg.world.dev/world-engine/cardinal/receipt"
+	"pkg.world.dev/world-engine/cardinal/txpool"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/cardinal/worldstage"
+	"pkg.world.dev/world-engine/sign"
+)
+
+var ErrSimulationRequiresRunningWorld = errors.New("transactions can only be simulated while the world is running")
+
+// interface guard
+var _ WorldContext = (*simulationContext)(nil)
+
+// SimulateTransaction runs the registered systems against a throwaway view of the game state with the given
+// transaction as the only transaction in the tick. The receipt the transaction would get and the state changes the
+// tick would make are returned, and then discarded; neither the game state, the receipt history, nor the events sent
+// to subscribers are affected. Systems run as though the next tick were executing, so the state changes include any
+// changes systems make regardless of the transaction.
+//
+// Simulations do not use the nonce of the transaction, so the same transaction can be simulated any number of times.
+// To keep simulations from holding off ticks, only one simulation runs at a time; types.ErrSimulationInProgress is
+// returned if another simulation is running. A system that panics while a transaction is simulated fails the
+// simulation instead of crashing the world.
+func (w *World) SimulateTransaction(id types.MessageID, msg any, sig *sign.Transaction) (
+	rec receipt.Receipt, changes []types.EntityStateChange, err error,
+) {
+	if w.worldStage.Current() != worldstage.Running {
+		return receipt.Receipt{}, nil, eris.Wrap(ErrSimulationRequiresRunningWorld, "")
+	}
+	if !w.simulationLock.TryLock() {
+		return receipt.Receipt{}, nil, eris.Wrap(types.ErrSimulationInProgress, "")
+	}
+	defer w.simulationLock.Unlock()
+
+	// Hold off ticks so the simulation runs against the state between two ticks.
+	w.tickLock.Lock()
+	defer w.tickLock.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			rec, changes = receipt.Receipt{}, nil
+			err = eris.Errorf("system %q panicked while simulating transaction: %v",
+				w.SystemManager.GetCurrentSystem(), r)
+		}
+	}()
+
+	store, err := w.entityStore.Fork()
+	if err != nil {
+		return receipt.Receipt{}, nil, eris.Wrap(err, "failed to fork game state")
+	}
+
+	// Transactions that target a later tick are simulated as though that tick had been reached.
+	tick := max(w.CurrentTick(), sig.TargetTick)
+	pool := txpool.New()
+	_, txHash := pool.AddTransaction(id, msg, sig)
+	txs := pool.CopyTransactions(context.Background(), tick)
+
+	wCtx := newSimulationContext(w, txs, store)
+	if err = w.SystemManager.runSystems(context.Background(), wCtx); err != nil {
+		return receipt.Receipt{}, nil, eris.Wrap(err, "failed to simulate transaction")
+	}
+
+	changes, err = store.StateChanges()
+	if err != nil {
+		return receipt.Receipt{}, nil, eris.Wrap(err, "failed to get simulated state changes")
+	}
+
+	// Transactions whose message is not handled by any system have an empty receipt.
+	rec, ok := wCtx.receipts.GetReceipt(txHash)
+	if !ok {
+		rec = receipt.Receipt{TxHash: txHash}
+	}
+	rec.PersonaTag = sig.PersonaTag
+	if msgType := w.MessageManager.GetMessageByID(id); msgType != nil {
+		rec.MsgName = msgType.FullName()
+	}
+	return rec, changes, nil
+}
+
+// simulationContext is the context systems run with when a transaction is simulated. State changes, receipts, events
+// and transactions added by systems are kept in the context instead of reaching the world.
+type simulationContext struct {
+	*worldContext
+	store    gamestate.Manager
+	receipts *receipt.History
+	events   *TickResults
+	// addedTxs holds the transactions systems add, which are discarded along with the rest of the simulation.
+	addedTxs *txpool.TxPool
+	// personas is a copy of the persona index, so personas created by the simulation do not reach the world.
+	personas personaIndex
+}
+
+func newSimulationContext(world *World, txPool *txpool.TxPool, store gamestate.Manager) *simulationContext {
+	//nolint:forcetypeassert // newWorldContextForTick always returns a *worldContext
+	wCtx := newWorldContextForTick(world, txPool).(*worldContext)
+	personas := maps.Clone(globalPersonaTagToAddressIndex)
+	if personas == nil {
+		personas = personaIndex{}
+	}
+	return &simulationContext{
+		worldContext: wCtx,
+		store:        store,
+		receipts:     receipt.NewHistory(world.CurrentTick(), 1),
+		events:       NewTickResults(world.CurrentTick()),
+		addedTxs:     txpool.New(),
+		personas:     personas,
+	}
+}
+
+func (ctx *simulationContext) EmitEvent(event map[string]any) error {
+	return ctx.events.AddEvent(event)
+}
+
+func (ctx *simulationContext) EmitStringEvent(e string) error {
+	return ctx.events.AddStringEvent(e)
+}
+
+func (ctx *simulationContext) emitPrivateEvent(personaTag string, event map[string]any) error {
+	return ctx.events.AddPrivateEvent(personaTag, event)
+}
+
+func (ctx *simulationContext) addMessageError(id types.TxHash, err error) {
+	ctx.receipts.AddError(id, err)
+}
+
+func (ctx *simulationContext) setMessageResult(id types.TxHash, a any) {
+	ctx.receipts.SetResult(id, a)
+}
+
+func (ctx *simulationContext) getTransactionReceipt(id types.TxHash) (any, []error, bool) {
+	rec, ok := ctx.receipts.GetReceipt(id)
+	if !ok {
+		return nil, nil, false
+	}
+	return rec.Result, rec.Errs, true
+}
+
+func (ctx *simulationContext) addTransaction(id types.MessageID, v any, sig *sign.Transaction) (uint64, types.TxHash) {
+	return ctx.addedTxs.AddTransaction(id, v, sig)
+}
+
+func (ctx *simulationContext) isSimulation() bool {
+	return true
+}
+
+func (ctx *simulationContext) personaIndex() personaIndex {
+	return ctx.personas
+}
+
+func (ctx *simulationContext) storeManager() gamestate.Manager {
+	return ctx.store
+}
+
+func (ctx *simulationContext) storeReader() gamestate.Reader {
+	return ctx.store
+}
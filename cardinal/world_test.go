@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/rotisserie/eris"
@@ -14,6 +15,7 @@ import (
 	"pkg.world.dev/world-engine/cardinal/filter"
 	"pkg.world.dev/world-engine/cardinal/types"
 	"pkg.world.dev/world-engine/cardinal/worldstage"
+	"pkg.world.dev/world-engine/sign"
 )
 
 type ScalarComponentStatic struct {
@@ -354,6 +356,74 @@ func TestSystemsPanicOnRedisError(t *testing.T) {
 	}
 }
 
+func TestTransactionsAreRestoredWhenTheyCannotBeMarkedForTheTick(t *testing.T) {
+	miniRedis := miniredis.RunT(t)
+	t.Setenv("REDIS_ADDRESS", miniRedis.Addr())
+	ctx := context.Background()
+
+	world, err := NewWorld(WithPort(getOpenPort(t)), WithTickChannel(make(chan time.Time)))
+	assert.NilError(t, err)
+	defer CleanupViper(t)
+	assert.NilError(t, RegisterMessage[Foo, Foo](world, "foo"))
+	executed := 0
+	assert.NilError(t, RegisterSystems(world, func(wCtx WorldContext) error {
+		return EachMessage[Foo, Foo](wCtx, func(TxData[Foo]) (Foo, error) {
+			executed++
+			return Foo{}, nil
+		})
+	}))
+	go func() {
+		err = world.StartGame()
+		assert.NilError(t, err)
+	}()
+	<-world.worldStage.NotifyOnStage(worldstage.Running)
+	defer func() {
+		world.Shutdown()
+	}()
+
+	fooMsg, ok := world.GetMessageByFullName("game.foo")
+	assert.True(t, ok)
+	tick, _ := world.AddTransaction(fooMsg.ID(), Foo{}, &sign.Transaction{PersonaTag: "foo", Nonce: 1})
+
+	// The tick fails because the transaction cannot be tagged with it in storage.
+	miniRedis.SetError("redis is down")
+	err = world.doTick(ctx, uint64(time.Now().UnixMilli()))
+	assert.ErrorContains(t, err, "redis is down")
+	assert.Equal(t, 0, executed)
+	miniRedis.SetError("")
+
+	// The transaction was put back into the pool, so it is executed in the tick it was assigned to once the tick runs.
+	assert.Equal(t, tick, world.CurrentTick())
+	assert.NilError(t, world.doTick(ctx, uint64(time.Now().UnixMilli())))
+	assert.Equal(t, 1, executed)
+}
+
+func TestRedisCommandsAreOnlyTimedWhenMetricsAreEnabled(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("enabled=%t", enabled), func(t *testing.T) {
+			t.Setenv("REDIS_ADDRESS", miniredis.RunT(t).Addr())
+			opts := []WorldOption{WithPort(getOpenPort(t))}
+			if enabled {
+				opts = append(opts, WithMetrics())
+			}
+			world, err := NewWorld(opts...)
+			assert.NilError(t, err)
+			defer CleanupViper(t)
+			assert.NilError(t, world.redisStorage.Client.Ping(context.Background()).Err())
+
+			families, err := world.metrics.Gatherer().Gather()
+			assert.NilError(t, err)
+			timed := false
+			for _, family := range families {
+				if family.GetName() == "cardinal_redis_duration_seconds" {
+					timed = true
+				}
+			}
+			assert.Equal(t, enabled, timed)
+		})
+	}
+}
+
 func doTickCapturePanic(ctx context.Context, world *World) (err error) {
 	defer func() {
 		if panicValue := recover(); panicValue != nil {
@@ -0,0 +1,187 @@
+package cardinal
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/rotisserie/eris"
+	"github.com/rs/zerolog/log"
+
+	"pkg.world.dev/world-engine/cardinal/codec"
+	"pkg.world.dev/world-engine/cardinal/txpool"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/cardinal/worldstage"
+	"pkg.world.dev/world-engine/sign"
+)
+
+// persistedTx is the form in which a transaction waiting in the transaction pool is stored. The message is stored by
+// its full name rather than its ID so that it can still be found if the message IDs change between restarts.
+type persistedTx struct {
+	MsgName    string            `json:"msgName"`
+	Msg        json.RawMessage   `json:"msg"`
+	Tx         *sign.Transaction `json:"tx"`
+	MsgVersion int               `json:"msgVersion,omitempty"`
+}
+
+// persistTransactions stores the given transactions so that they can be reloaded if Cardinal restarts before they are
+// executed. Transactions added before the world is running are not persisted, as they would otherwise be reloaded into
+// the pool they are already in. Failing to persist a transaction does not prevent it from being executed, so errors are
+// only logged.
+func (w *World) persistTransactions(txs ...txpool.TxData) {
+	if stage := w.worldStage.Current(); stage != worldstage.Running && stage != worldstage.ShuttingDown {
+		return
+	}
+	toStore := make(map[string][]byte, len(txs))
+	for _, tx := range txs {
+		// Transactions that originate from the EVM are not persisted, as the result of the transaction is sent back
+		// to the EVM over a connection that does not survive a restart.
+		if tx.EVMSourceTxHash != "" {
+			continue
+		}
+		txHash := tx.Tx.HashHex()
+		bz, err := w.encodePersistedTx(tx)
+		if err != nil {
+			log.Err(err).Str("tx_hash", txHash).Msg("failed to encode pending transaction")
+			continue
+		}
+		toStore[txHash] = bz
+	}
+	if err := w.redisStorage.AddPendingTransactions(toStore); err != nil {
+		log.Err(err).Msg("failed to persist pending transactions")
+	}
+}
+
+func (w *World) encodePersistedTx(tx txpool.TxData) ([]byte, error) {
+	msgType, ok := w.GetMessageByID(tx.MsgID)
+	if !ok {
+		return nil, eris.Errorf("message with id %d not found", tx.MsgID)
+	}
+	msgBz, err := codec.Encode(tx.Msg)
+	if err != nil {
+		return nil, err
+	}
+	return codec.Encode(persistedTx{
+		MsgName:    msgType.FullName(),
+		Msg:        msgBz,
+		Tx:         tx.Tx,
+		MsgVersion: tx.MsgVersion,
+	})
+}
+
+// markTransactionsForTick tags the persisted transactions in the given copy of the pool with the tick they are being
+// executed in. This must happen before the tick is finalized: a persisted transaction tagged with a tick that has been
+// finalized has already been executed and must not be reloaded.
+func (w *World) markTransactionsForTick(txPool *txpool.TxPool, tick uint64) error {
+	if !w.shouldPersistTransactions() {
+		return nil
+	}
+	return w.redisStorage.SetPendingTransactionsTick(persistedTxHashes(txPool), tick)
+}
+
+// removePersistedTransactions removes the persisted transactions in the given copy of the pool once its tick has been
+// finalized. Transactions that fail to be removed are discarded the next time the pool is reloaded, so errors are only
+// logged.
+func (w *World) removePersistedTransactions(txPool *txpool.TxPool) {
+	if !w.shouldPersistTransactions() {
+		return
+	}
+	if err := w.redisStorage.RemovePendingTransactions(persistedTxHashes(txPool)); err != nil {
+		log.Err(err).Msg("failed to remove executed transactions from storage")
+	}
+}
+
+// shouldPersistTransactions reports whether transactions should currently be persisted. Transactions that are
+// replayed while recovering from the base shard have already been persisted there, so they are not stored again.
+func (w *World) shouldPersistTransactions() bool {
+	return w.worldStage.Current() != worldstage.Recovering
+}
+
+// reloadTransactions adds the persisted transactions that were not executed before Cardinal last shut down back into
+// the transaction pool. Transactions tagged with a tick that has already been finalized were executed and are removed
+// instead. The nonces of reloaded transactions remain marked as used, so a client resubmitting one of them is
+// rejected rather than having it executed twice.
+func (w *World) reloadTransactions() error {
+	stored, ticks, err := w.redisStorage.GetPendingTransactions()
+	if err != nil {
+		return eris.Wrap(err, "failed to get pending transactions")
+	}
+
+	lastFinalizedTick := w.CurrentTick()
+	executed := make([]string, 0)
+	reloaded := make([]txpool.TxData, 0, len(stored))
+	for txHash, bz := range stored {
+		if tick, ok := ticks[txHash]; ok && tick < lastFinalizedTick {
+			executed = append(executed, txHash)
+			continue
+		}
+		tx, err := w.decodePersistedTx(types.TxHash(txHash), bz)
+		if err != nil {
+			log.Err(err).Str("tx_hash", txHash).Msg("discarding pending transaction that cannot be decoded")
+			executed = append(executed, txHash)
+			continue
+		}
+		reloaded = append(reloaded, tx)
+	}
+
+	// Transactions from the same persona are reloaded in nonce order so they are executed in the order they were
+	// most likely submitted in.
+	sort.Slice(reloaded, func(i, j int) bool {
+		if reloaded[i].Tx.PersonaTag != reloaded[j].Tx.PersonaTag {
+			return reloaded[i].Tx.PersonaTag < reloaded[j].Tx.PersonaTag
+		}
+		return reloaded[i].Tx.Nonce < reloaded[j].Tx.Nonce
+	})
+	w.txPool.AddTransactions(reloaded)
+
+	if err := w.redisStorage.RemovePendingTransactions(executed); err != nil {
+		return eris.Wrap(err, "failed to remove executed transactions from storage")
+	}
+
+	if len(reloaded) > 0 {
+		log.Info().Int("tx_count", len(reloaded)).Msg("Reloaded pending transactions")
+	}
+	return nil
+}
+
+func (w *World) decodePersistedTx(txHash types.TxHash, bz []byte) (txpool.TxData, error) {
+	ptx, err := codec.Decode[persistedTx](bz)
+	if err != nil {
+		return txpool.TxData{}, err
+	}
+	if ptx.Tx == nil {
+		return txpool.TxData{}, eris.New("pending transaction is missing its signed transaction")
+	}
+	msgType, ok := w.GetMessageByFullName(ptx.MsgName)
+	if !ok {
+		return txpool.TxData{}, eris.Errorf("message %q not found", ptx.MsgName)
+	}
+	msg, err := msgType.Decode(ptx.Msg)
+	if err != nil {
+		return txpool.TxData{}, err
+	}
+	return txpool.TxData{
+		MsgID:      msgType.ID(),
+		Msg:        msg,
+		TxHash:     txHash,
+		Tx:         ptx.Tx,
+		MsgVersion: ptx.MsgVersion,
+	}, nil
+}
+
+// persistedTxHashes returns the hashes of the transactions in the given copy of the pool that may have been persisted.
+func persistedTxHashes(txPool *txpool.TxPool) []string {
+	txHashes := make([]string, 0, txPool.GetAmountOfTxs())
+	for _, txs := range txPool.Transactions() {
+		for _, tx := range txs {
+			if tx.EVMSourceTxHash == "" {
+				txHashes = append(txHashes, string(tx.TxHash))
+			}
+		}
+	}
+	for _, tx := range txPool.ExpiredTransactions() {
+		if tx.EVMSourceTxHash == "" {
+			txHashes = append(txHashes, string(tx.TxHash))
+		}
+	}
+	return txHashes
+}
@@ -0,0 +1,139 @@
+package cardinal_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"pkg.world.dev/world-engine/assert"
+	"pkg.world.dev/world-engine/cardinal"
+	"pkg.world.dev/world-engine/cardinal/types"
+	"pkg.world.dev/world-engine/sign"
+)
+
+func TestPendingTransactionsAreReloadedAfterRestart(t *testing.T) {
+	rs := miniredis.RunT(t)
+	executed := map[string]int{}
+
+	// newFixture creates a world backed by rs that counts how many times each foo message is executed.
+	newFixture := func() (*cardinal.TestFixture, types.Message) {
+		tf := cardinal.NewTestFixture(t, rs)
+		assert.NilError(t, cardinal.RegisterMessage[fooMessage, fooResponse](tf.World, "foo"))
+		err := cardinal.RegisterSystems(tf.World, func(wCtx cardinal.WorldContext) error {
+			return cardinal.EachMessage[fooMessage, fooResponse](wCtx,
+				func(tx cardinal.TxData[fooMessage]) (fooResponse, error) {
+					executed[tx.Msg.Bar]++
+					return fooResponse{}, nil
+				})
+		})
+		assert.NilError(t, err)
+		msg, ok := tf.World.GetMessageByFullName("game.foo")
+		assert.True(t, ok)
+		return tf, msg
+	}
+	newTx := func(bar string, nonce uint64) (fooMessage, *sign.Transaction) {
+		body, err := json.Marshal(fooMessage{Bar: bar})
+		assert.NilError(t, err)
+		return fooMessage{Bar: bar}, &sign.Transaction{PersonaTag: "foo", Namespace: "ns", Nonce: nonce, Body: body}
+	}
+
+	tf1, fooMsg := newFixture()
+	tf1.StartWorld()
+	msg, tx := newTx("executed", 1)
+	executedHash := tf1.AddTransaction(fooMsg.ID(), msg, tx)
+	storedTx := rs.HGet("PENDING_TXS", string(executedHash))
+	assert.NotEmpty(t, storedTx)
+	tf1.DoTick()
+	assert.Equal(t, 1, executed["executed"])
+
+	// Simulate Cardinal stopping after the tick was finalized, but before the executed transaction was removed from
+	// storage.
+	rs.HSet("PENDING_TXS", string(executedHash), storedTx)
+	rs.HSet("PENDING_TX_TICKS", string(executedHash), "0")
+
+	// This transaction is accepted, but Cardinal stops before it is executed.
+	msg, tx = newTx("pending", 2)
+	tf1.AddTransaction(fooMsg.ID(), msg, tx)
+	tf1.World.Shutdown()
+	assert.Equal(t, 0, executed["pending"])
+
+	tf2, _ := newFixture()
+	tf2.DoTick()
+	assert.Equal(t, 1, executed["pending"])
+	assert.Equal(t, 1, executed["executed"])
+	_, err := rs.HKeys("PENDING_TXS")
+	assert.ErrorIs(t, err, miniredis.ErrKeyNotFound)
+
+	tf2.DoTick()
+	assert.Equal(t, 1, executed["pending"])
+}
+
+func TestTransactionsAreExecutedInTheReturnedTick(t *testing.T) {
+	const submitters, txsPerSubmitter = 10, 50
+	tf := cardinal.NewTestFixture(t, nil)
+	world := tf.World
+	assert.NilError(t, cardinal.RegisterMessage[fooMessage, fooResponse](world, "foo"))
+
+	// executedIn records the tick each transaction was executed in.
+	var mux sync.Mutex
+	executedIn := map[types.TxHash]uint64{}
+	err := cardinal.RegisterSystems(world, func(wCtx cardinal.WorldContext) error {
+		// Sleep to widen the window between the transaction pool being copied and the tick being incremented.
+		time.Sleep(time.Millisecond)
+		mux.Lock()
+		defer mux.Unlock()
+		return cardinal.EachMessage[fooMessage, fooResponse](wCtx,
+			func(tx cardinal.TxData[fooMessage]) (fooResponse, error) {
+				executedIn[tx.Hash] = wCtx.CurrentTick()
+				return fooResponse{}, nil
+			})
+	})
+	assert.NilError(t, err)
+	fooMsg, ok := world.GetMessageByFullName("game.foo")
+	assert.True(t, ok)
+	tf.StartWorld()
+
+	// returnedTick records the tick returned when each transaction was added.
+	returnedTick := make([]map[types.TxHash]uint64, submitters)
+	var wg sync.WaitGroup
+	for i := 0; i < submitters; i++ {
+		returnedTick[i] = map[types.TxHash]uint64{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for nonce := uint64(0); nonce < txsPerSubmitter; nonce++ {
+				tx := &sign.Transaction{PersonaTag: fmt.Sprintf("persona-%d", i), Namespace: "ns", Nonce: nonce}
+				tick, txHash := world.AddTransaction(fooMsg.ID(), fooMessage{}, tx)
+				returnedTick[i][txHash] = tick
+			}
+		}(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		tf.DoTick()
+	}
+	// Every transaction has been added, so one more tick executes any that were added after the last copy.
+	tf.DoTick()
+
+	mux.Lock()
+	defer mux.Unlock()
+	assert.Equal(t, submitters*txsPerSubmitter, len(executedIn))
+	for _, txs := range returnedTick {
+		for txHash, tick := range txs {
+			assert.Equal(t, tick, executedIn[txHash], "tx %s", txHash)
+		}
+	}
+}
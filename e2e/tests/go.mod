@@ -5,6 +5,8 @@ go 1.22.1
 replace (
 	pkg.world.dev/world-engine/cardinal => ../../cardinal
 	pkg.world.dev/world-engine/evm => ../../evm
+	pkg.world.dev/world-engine/rift => ../../rift
+	pkg.world.dev/world-engine/sign => ../../sign
 )
 
 // external, necessary replacements
@@ -26,7 +28,7 @@ require (
 	github.com/cosmos/cosmos-sdk v0.50.7-0.20240528102556-d180df817efc
 	github.com/ethereum/go-ethereum v1.13.10
 	github.com/rotisserie/eris v0.5.4
-	google.golang.org/grpc v1.63.2
+	google.golang.org/grpc v1.64.0
 	gotest.tools/v3 v3.5.1
 	nhooyr.io/websocket v1.8.10
 	pkg.world.dev/world-engine/assert v1.0.0
@@ -180,7 +182,7 @@ require (
 	golang.org/x/term v0.20.0 // indirect
 	golang.org/x/text v0.15.0 // indirect
 	google.golang.org/genproto v0.0.0-20240227224415-6ceb2ff114de // indirect
-	google.golang.org/genproto/googleapis/api v0.0.0-20240227224415-6ceb2ff114de // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20240318140521-94a12d6c2237 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240401170217-c3f982113cda // indirect
 	google.golang.org/protobuf v1.34.1 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
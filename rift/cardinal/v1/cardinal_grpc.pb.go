@@ -0,0 +1,405 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: cardinal/v1/cardinal.proto
+
+package cardinalv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// CardinalClient is the client API for Cardinal service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CardinalClient interface {
+	// SendTransaction submits a transaction containing a message. It mirrors POST /tx/{group}/{name}.
+	SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error)
+	// SendTransactionBatch submits transactions from a single persona that are all executed in the same tick. It
+	// mirrors POST /tx/batch.
+	SendTransactionBatch(ctx context.Context, in *SendTransactionBatchRequest, opts ...grpc.CallOption) (*SendTransactionBatchResponse, error)
+	// Query executes a query. It mirrors POST /query/{group}/{name}.
+	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
+	// EvaluateCQL returns the entities that match a CQL (Cardinal Query Language) query. It mirrors POST /cql.
+	EvaluateCQL(ctx context.Context, in *EvaluateCQLRequest, opts ...grpc.CallOption) (*EvaluateCQLResponse, error)
+	// GetWorld returns the registered components, messages, queries and events of the world. It mirrors GET /world.
+	GetWorld(ctx context.Context, in *GetWorldRequest, opts ...grpc.CallOption) (*GetWorldResponse, error)
+	// GetReceipt returns the receipt of a transaction, or its target tick while it is pending. It mirrors
+	// GET /receipt/{txHash}.
+	GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*GetReceiptResponse, error)
+	// ListReceipts returns the receipts of the ticks since a given tick. It mirrors POST /query/receipts/list.
+	ListReceipts(ctx context.Context, in *ListReceiptsRequest, opts ...grpc.CallOption) (*ListReceiptsResponse, error)
+	// StreamTickResults streams the results of each tick once it completes. It mirrors the /events websocket.
+	StreamTickResults(ctx context.Context, in *StreamTickResultsRequest, opts ...grpc.CallOption) (Cardinal_StreamTickResultsClient, error)
+}
+
+type cardinalClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCardinalClient(cc grpc.ClientConnInterface) CardinalClient {
+	return &cardinalClient{cc}
+}
+
+func (c *cardinalClient) SendTransaction(ctx context.Context, in *SendTransactionRequest, opts ...grpc.CallOption) (*SendTransactionResponse, error) {
+	out := new(SendTransactionResponse)
+	err := c.cc.Invoke(ctx, "/world.engine.cardinal.v1.Cardinal/SendTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardinalClient) SendTransactionBatch(ctx context.Context, in *SendTransactionBatchRequest, opts ...grpc.CallOption) (*SendTransactionBatchResponse, error) {
+	out := new(SendTransactionBatchResponse)
+	err := c.cc.Invoke(ctx, "/world.engine.cardinal.v1.Cardinal/SendTransactionBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardinalClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
+	out := new(QueryResponse)
+	err := c.cc.Invoke(ctx, "/world.engine.cardinal.v1.Cardinal/Query", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardinalClient) EvaluateCQL(ctx context.Context, in *EvaluateCQLRequest, opts ...grpc.CallOption) (*EvaluateCQLResponse, error) {
+	out := new(EvaluateCQLResponse)
+	err := c.cc.Invoke(ctx, "/world.engine.cardinal.v1.Cardinal/EvaluateCQL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardinalClient) GetWorld(ctx context.Context, in *GetWorldRequest, opts ...grpc.CallOption) (*GetWorldResponse, error) {
+	out := new(GetWorldResponse)
+	err := c.cc.Invoke(ctx, "/world.engine.cardinal.v1.Cardinal/GetWorld", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardinalClient) GetReceipt(ctx context.Context, in *GetReceiptRequest, opts ...grpc.CallOption) (*GetReceiptResponse, error) {
+	out := new(GetReceiptResponse)
+	err := c.cc.Invoke(ctx, "/world.engine.cardinal.v1.Cardinal/GetReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardinalClient) ListReceipts(ctx context.Context, in *ListReceiptsRequest, opts ...grpc.CallOption) (*ListReceiptsResponse, error) {
+	out := new(ListReceiptsResponse)
+	err := c.cc.Invoke(ctx, "/world.engine.cardinal.v1.Cardinal/ListReceipts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cardinalClient) StreamTickResults(ctx context.Context, in *StreamTickResultsRequest, opts ...grpc.CallOption) (Cardinal_StreamTickResultsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Cardinal_ServiceDesc.Streams[0], "/world.engine.cardinal.v1.Cardinal/StreamTickResults", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cardinalStreamTickResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Cardinal_StreamTickResultsClient interface {
+	Recv() (*StreamTickResultsResponse, error)
+	grpc.ClientStream
+}
+
+type cardinalStreamTickResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *cardinalStreamTickResultsClient) Recv() (*StreamTickResultsResponse, error) {
+	m := new(StreamTickResultsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CardinalServer is the server API for Cardinal service.
+// All implementations must embed UnimplementedCardinalServer
+// for forward compatibility
+type CardinalServer interface {
+	// SendTransaction submits a transaction containing a message. It mirrors POST /tx/{group}/{name}.
+	SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error)
+	// SendTransactionBatch submits transactions from a single persona that are all executed in the same tick. It
+	// mirrors POST /tx/batch.
+	SendTransactionBatch(context.Context, *SendTransactionBatchRequest) (*SendTransactionBatchResponse, error)
+	// Query executes a query. It mirrors POST /query/{group}/{name}.
+	Query(context.Context, *QueryRequest) (*QueryResponse, error)
+	// EvaluateCQL returns the entities that match a CQL (Cardinal Query Language) query. It mirrors POST /cql.
+	EvaluateCQL(context.Context, *EvaluateCQLRequest) (*EvaluateCQLResponse, error)
+	// GetWorld returns the registered components, messages, queries and events of the world. It mirrors GET /world.
+	GetWorld(context.Context, *GetWorldRequest) (*GetWorldResponse, error)
+	// GetReceipt returns the receipt of a transaction, or its target tick while it is pending. It mirrors
+	// GET /receipt/{txHash}.
+	GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error)
+	// ListReceipts returns the receipts of the ticks since a given tick. It mirrors POST /query/receipts/list.
+	ListReceipts(context.Context, *ListReceiptsRequest) (*ListReceiptsResponse, error)
+	// StreamTickResults streams the results of each tick once it completes. It mirrors the /events websocket.
+	StreamTickResults(*StreamTickResultsRequest, Cardinal_StreamTickResultsServer) error
+	mustEmbedUnimplementedCardinalServer()
+}
+
+// UnimplementedCardinalServer must be embedded to have forward compatible implementations.
+type UnimplementedCardinalServer struct {
+}
+
+func (UnimplementedCardinalServer) SendTransaction(context.Context, *SendTransactionRequest) (*SendTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
+}
+func (UnimplementedCardinalServer) SendTransactionBatch(context.Context, *SendTransactionBatchRequest) (*SendTransactionBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTransactionBatch not implemented")
+}
+func (UnimplementedCardinalServer) Query(context.Context, *QueryRequest) (*QueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
+}
+func (UnimplementedCardinalServer) EvaluateCQL(context.Context, *EvaluateCQLRequest) (*EvaluateCQLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateCQL not implemented")
+}
+func (UnimplementedCardinalServer) GetWorld(context.Context, *GetWorldRequest) (*GetWorldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorld not implemented")
+}
+func (UnimplementedCardinalServer) GetReceipt(context.Context, *GetReceiptRequest) (*GetReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReceipt not implemented")
+}
+func (UnimplementedCardinalServer) ListReceipts(context.Context, *ListReceiptsRequest) (*ListReceiptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReceipts not implemented")
+}
+func (UnimplementedCardinalServer) StreamTickResults(*StreamTickResultsRequest, Cardinal_StreamTickResultsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTickResults not implemented")
+}
+func (UnimplementedCardinalServer) mustEmbedUnimplementedCardinalServer() {}
+
+// UnsafeCardinalServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CardinalServer will
+// result in compilation errors.
+type UnsafeCardinalServer interface {
+	mustEmbedUnimplementedCardinalServer()
+}
+
+func RegisterCardinalServer(s grpc.ServiceRegistrar, srv CardinalServer) {
+	s.RegisterService(&Cardinal_ServiceDesc, srv)
+}
+
+func _Cardinal_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardinalServer).SendTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/world.engine.cardinal.v1.Cardinal/SendTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardinalServer).SendTransaction(ctx, req.(*SendTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cardinal_SendTransactionBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTransactionBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardinalServer).SendTransactionBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/world.engine.cardinal.v1.Cardinal/SendTransactionBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardinalServer).SendTransactionBatch(ctx, req.(*SendTransactionBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cardinal_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardinalServer).Query(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/world.engine.cardinal.v1.Cardinal/Query",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardinalServer).Query(ctx, req.(*QueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cardinal_EvaluateCQL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateCQLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardinalServer).EvaluateCQL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/world.engine.cardinal.v1.Cardinal/EvaluateCQL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardinalServer).EvaluateCQL(ctx, req.(*EvaluateCQLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cardinal_GetWorld_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWorldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardinalServer).GetWorld(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/world.engine.cardinal.v1.Cardinal/GetWorld",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardinalServer).GetWorld(ctx, req.(*GetWorldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cardinal_GetReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardinalServer).GetReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/world.engine.cardinal.v1.Cardinal/GetReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardinalServer).GetReceipt(ctx, req.(*GetReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cardinal_ListReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReceiptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CardinalServer).ListReceipts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/world.engine.cardinal.v1.Cardinal/ListReceipts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CardinalServer).ListReceipts(ctx, req.(*ListReceiptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Cardinal_StreamTickResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTickResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CardinalServer).StreamTickResults(m, &cardinalStreamTickResultsServer{stream})
+}
+
+type Cardinal_StreamTickResultsServer interface {
+	Send(*StreamTickResultsResponse) error
+	grpc.ServerStream
+}
+
+type cardinalStreamTickResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *cardinalStreamTickResultsServer) Send(m *StreamTickResultsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Cardinal_ServiceDesc is the grpc.ServiceDesc for Cardinal service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Cardinal_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "world.engine.cardinal.v1.Cardinal",
+	HandlerType: (*CardinalServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendTransaction",
+			Handler:    _Cardinal_SendTransaction_Handler,
+		},
+		{
+			MethodName: "SendTransactionBatch",
+			Handler:    _Cardinal_SendTransactionBatch_Handler,
+		},
+		{
+			MethodName: "Query",
+			Handler:    _Cardinal_Query_Handler,
+		},
+		{
+			MethodName: "EvaluateCQL",
+			Handler:    _Cardinal_EvaluateCQL_Handler,
+		},
+		{
+			MethodName: "GetWorld",
+			Handler:    _Cardinal_GetWorld_Handler,
+		},
+		{
+			MethodName: "GetReceipt",
+			Handler:    _Cardinal_GetReceipt_Handler,
+		},
+		{
+			MethodName: "ListReceipts",
+			Handler:    _Cardinal_ListReceipts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTickResults",
+			Handler:       _Cardinal_StreamTickResults_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cardinal/v1/cardinal.proto",
+}
@@ -40,6 +40,29 @@ type Transaction struct {
 	Signature  string          `json:"signature"` // hex encoded string
 	Hash       common.Hash     `json:"hash,omitempty" swaggertype:"string"`
 	Body       json.RawMessage `json:"body" swaggertype:"object"` // json string
+	// TargetTick is the tick this transaction should be executed in. A zero value means the transaction will be
+	// executed in the next available tick.
+	TargetTick uint64 `json:"targetTick,omitempty"`
+	// ExpiryTick is the last tick this transaction can be executed in. A zero value means the transaction never
+	// expires.
+	ExpiryTick uint64 `json:"expiryTick,omitempty"`
+}
+
+// Option is used to set optional fields of a Transaction before it is signed.
+type Option func(*Transaction)
+
+// WithTargetTick sets the tick the transaction should be executed in.
+func WithTargetTick(tick uint64) Option {
+	return func(tx *Transaction) {
+		tx.TargetTick = tick
+	}
+}
+
+// WithExpiryTick sets the last tick the transaction can be executed in.
+func WithExpiryTick(tick uint64) Option {
+	return func(tx *Transaction) {
+		tx.ExpiryTick = tick
+	}
 }
 
 func UnmarshalTransaction(bz []byte) (*Transaction, error) {
@@ -86,6 +109,8 @@ func MappedTransaction(tx map[string]interface{}) (*Transaction, error) {
 		"nonce":      true,
 		"body":       true,
 		"hash":       true,
+		"targetTick": true,
+		"expiryTick": true,
 	}
 	for key := range tx {
 		if !transactionKeys[key] {
@@ -147,8 +172,15 @@ func normalizeJSON(data any) ([]byte, error) {
 	return normalizedBz, nil
 }
 
-// sign uses the given private key to sign the personaTag, namespace, nonce, and data.
-func sign(pk *ecdsa.PrivateKey, personaTag, namespace string, nonce uint64, data any) (*Transaction, error) {
+// sign uses the given private key to sign the personaTag, namespace, nonce, and data. Any optional fields set by opts
+// are also included in the signature.
+func sign(
+	pk *ecdsa.PrivateKey,
+	personaTag, namespace string,
+	nonce uint64,
+	data any,
+	opts ...Option,
+) (*Transaction, error) {
 	if data == nil || reflect.ValueOf(data).IsZero() {
 		return nil, ErrCannotSignEmptyBody
 	}
@@ -168,6 +200,9 @@ func sign(pk *ecdsa.PrivateKey, personaTag, namespace string, nonce uint64, data
 		Nonce:      nonce,
 		Body:       bz,
 	}
+	for _, opt := range opts {
+		opt(sp)
+	}
 	sp.populateHash()
 	buf, err := crypto.Sign(sp.Hash.Bytes(), pk)
 	if err != nil {
@@ -178,8 +213,14 @@ func sign(pk *ecdsa.PrivateKey, personaTag, namespace string, nonce uint64, data
 }
 
 // NewSystemTransaction signs a given body, and nonce with the given private key using the SystemPersonaTag.
-func NewSystemTransaction(pk *ecdsa.PrivateKey, namespace string, nonce uint64, data any) (*Transaction, error) {
-	return sign(pk, SystemPersonaTag, namespace, nonce, data)
+func NewSystemTransaction(
+	pk *ecdsa.PrivateKey,
+	namespace string,
+	nonce uint64,
+	data any,
+	opts ...Option,
+) (*Transaction, error) {
+	return sign(pk, SystemPersonaTag, namespace, nonce, data, opts...)
 }
 
 // NewTransaction signs a given body, tag, and nonce with the given private key.
@@ -189,11 +230,12 @@ func NewTransaction(
 	namespace string,
 	nonce uint64,
 	data any,
+	opts ...Option,
 ) (*Transaction, error) {
 	if len(personaTag) == 0 || personaTag == SystemPersonaTag {
 		return nil, ErrInvalidPersonaTag
 	}
-	return sign(pk, personaTag, namespace, nonce, data)
+	return sign(pk, personaTag, namespace, nonce, data, opts...)
 }
 
 func (s *Transaction) IsSystemTransaction() bool {
@@ -251,10 +293,19 @@ func (s *Transaction) Verify(hexAddress string) error {
 }
 
 func (s *Transaction) populateHash() {
-	s.Hash = crypto.Keccak256Hash(
+	data := [][]byte{
 		[]byte(s.PersonaTag),
 		[]byte(s.Namespace),
 		[]byte(strconv.FormatUint(s.Nonce, 10)),
 		s.Body,
-	)
+	}
+	// Optional fields are only hashed when they are set so the hashes of transactions that do not use them remain
+	// unchanged. Each one is prefixed with its name so different combinations of optional fields cannot collide.
+	if s.TargetTick != 0 {
+		data = append(data, []byte("targetTick:"+strconv.FormatUint(s.TargetTick, 10)))
+	}
+	if s.ExpiryTick != 0 {
+		data = append(data, []byte("expiryTick:"+strconv.FormatUint(s.ExpiryTick, 10)))
+	}
+	s.Hash = crypto.Keccak256Hash(data...)
 }
@@ -67,6 +67,70 @@ func TestCanParseAMappedTransaction(t *testing.T) {
 	assert.DeepEqual(t, sp, gotSP)
 }
 
+func TestTargetTickIsSigned(t *testing.T) {
+	goodKey, err := crypto.GenerateKey()
+	assert.NilError(t, err)
+	body := `{"msg": "this is a request body"}`
+	personaTag := "my-tag"
+	namespace := "my-namespace"
+	nonce := uint64(100)
+
+	sp, err := NewTransaction(goodKey, personaTag, namespace, nonce, body, WithTargetTick(55))
+	assert.NilError(t, err)
+	assert.Equal(t, sp.TargetTick, uint64(55))
+
+	untargeted, err := NewTransaction(goodKey, personaTag, namespace, nonce, body)
+	assert.NilError(t, err)
+	assert.Check(t, sp.Hash != untargeted.Hash)
+
+	// The target tick must survive both the JSON and the map round trip.
+	bz, err := json.Marshal(sp)
+	assert.NilError(t, err)
+	gotSP, err := UnmarshalTransaction(bz)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, sp, gotSP)
+
+	asMap := map[string]any{}
+	assert.NilError(t, json.Unmarshal(bz, &asMap))
+	gotSP, err = MappedTransaction(asMap)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, sp, gotSP)
+
+	// Tampering with the target tick invalidates the signature.
+	gotSP.TargetTick = 56
+	gotSP.Hash = common.Hash{}
+	goodAddressHex := crypto.PubkeyToAddress(goodKey.PublicKey).Hex()
+	err = eris.Unwrap(gotSP.Verify(goodAddressHex))
+	assert.ErrorIs(t, err, ErrSignatureValidationFailed)
+}
+
+func TestExpiryTickIsSigned(t *testing.T) {
+	goodKey, err := crypto.GenerateKey()
+	assert.NilError(t, err)
+	body := `{"msg": "this is a request body"}`
+
+	sp, err := NewTransaction(goodKey, "my-tag", "my-namespace", 100, body, WithExpiryTick(12))
+	assert.NilError(t, err)
+	assert.Equal(t, sp.ExpiryTick, uint64(12))
+
+	bz, err := json.Marshal(sp)
+	assert.NilError(t, err)
+	asMap := map[string]any{}
+	assert.NilError(t, json.Unmarshal(bz, &asMap))
+	gotSP, err := MappedTransaction(asMap)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, sp, gotSP)
+
+	// The target and expiry ticks are hashed separately, so their digits cannot be shifted between the two.
+	first, err := NewTransaction(goodKey, "my-tag", "my-namespace", 100, body,
+		WithTargetTick(1), WithExpiryTick(23))
+	assert.NilError(t, err)
+	second, err := NewTransaction(goodKey, "my-tag", "my-namespace", 100, body,
+		WithTargetTick(12), WithExpiryTick(3))
+	assert.NilError(t, err)
+	assert.Check(t, first.Hash != second.Hash)
+}
+
 func TestCanGetHashHex(t *testing.T) {
 	goodKey, err := crypto.GenerateKey()
 	assert.NilError(t, err)